@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -15,10 +16,13 @@ import (
 	"github.com/codewithboateng/jclift/internal/security"
 
 	"github.com/codewithboateng/jclift/internal/cost"
+	"github.com/codewithboateng/jclift/internal/fix"
 	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/lineage"
 	"github.com/codewithboateng/jclift/internal/parser"
 	"github.com/codewithboateng/jclift/internal/reporting"
 	"github.com/codewithboateng/jclift/internal/rules"
+	"github.com/codewithboateng/jclift/internal/rules/rulestest"
 	"github.com/codewithboateng/jclift/internal/rulesdsl"
 	"github.com/codewithboateng/jclift/internal/shared"
 	"github.com/codewithboateng/jclift/internal/storage"
@@ -40,6 +44,12 @@ func main() {
 		reportCmd(os.Args[2:])
 	case "diff":
 		diffCmd(os.Args[2:])
+	case "lineage":
+		lineageCmd(os.Args[2:])
+	case "fix":
+		fixCmd(os.Args[2:])
+	case "rules":
+		rulesCmd(os.Args[2:])
 	case "version":
 		fmt.Println("jclift (MVP skeleton) IR:", ir.Version)
 	default:
@@ -52,9 +62,12 @@ func usage() {
 	fmt.Fprintf(os.Stderr, `jclift – JCL Cost/Risk Analyzer
 
 Usage:
-  jclift analyze --path <input-dir> --out <reports-dir> [--db ./jclift.db] [--mips-usd 250] [--config ./configs/jclift.yaml]
+  jclift analyze --path <input-dir> --out <reports-dir> [--db ./jclift.db] [--mips-usd 250] [--config ./configs/jclift.yaml] [--fail-on-parse-errors] [--profile-rules]
   jclift report  --run <run-id>     --out <reports-dir> [--db ./jclift.db] [--config ./configs/jclift.yaml]
   jclift diff    --base <run-id> --head <run-id> --out <reports-dir> [--db ./jclift.db] [--config ./configs/jclift.yaml]
+  jclift lineage [--run <run-id>] [--dataset <dsn>] [--depth N] [--format text|json|dot|mermaid] [--out <file>] [--db ./jclift.db]
+  jclift fix     [--run <run-id>] [--rule ID,...] [--dry-run | --apply] [--out <file>] [--db ./jclift.db]
+  jclift rules test <fixtures-dir> [--rules-pack <pack.yaml>] [--config ./configs/jclift.yaml] [-v]
   jclift version
 `)
 }
@@ -62,7 +75,7 @@ Usage:
 func analyzeCmd(args []string) {
 	fs := flag.NewFlagSet("analyze", flag.ExitOnError)
 	configPath   := fs.String("config", "", "Path to YAML config (optional)")
-	inPath       := fs.String("path", "", "Path to input JCL directory")
+	inPath       := fs.String("path", "", "Path to input JCL directory (comma-separated for several)")
 	outDir       := fs.String("out", "", "Output directory for reports")
 	dbPath       := fs.String("db", "", "SQLite database path")
 	mipsUSD      := fs.Float64("mips-usd", 0, "USD per MIPS unit (optional)")
@@ -70,6 +83,10 @@ func analyzeCmd(args []string) {
 	rulesDisable := fs.String("rules-disable", "", "Comma-separated rule IDs to disable")
 	rulesPack    := fs.String("rules-pack", "", "Path to YAML rule pack (DSL)") // ✅ define BEFORE Parse
 	failOn       := fs.Bool("fail-on-findings", false, "Exit non-zero if any findings remain after threshold/disable")
+	failOnParse  := fs.Bool("fail-on-parse-errors", false, "Exit non-zero if the parser reported any ERROR diagnostics")
+	encoding     := fs.String("encoding", "", "Input encoding: auto|ascii|ibm1047|ibm037 (default auto)")
+	workers      := fs.Int("workers", 0, "Parallel workers for parse/cost/rules (default: number of CPUs)")
+	profile      := fs.Bool("profile-rules", false, "Print per-rule evaluation time, invocations, findings and panics")
 	_ = fs.Parse(args)
 
 	// Load config + init logger
@@ -78,10 +95,11 @@ func analyzeCmd(args []string) {
 	_ = logger
 
 	// Precedence: flags > config > defaults
-	if *inPath == "" && len(cfg.Analysis.Sources) > 0 { *inPath = cfg.Analysis.Sources[0] }
 	if *outDir == "" { *outDir = cfg.Reporting.OutDir }
 	if *dbPath == "" { *dbPath = cfg.Database.DSN }
 	if *mipsUSD == 0 && cfg.Analysis.MIPSToUSD > 0 { *mipsUSD = cfg.Analysis.MIPSToUSD }
+	if *encoding == "" { *encoding = cfg.Analysis.Encoding }
+	if *workers <= 0 { *workers = cfg.Analysis.Workers }
 
 	// Severity threshold + disabled rules
 	sth := cfg.Rules.SeverityThreshold
@@ -94,28 +112,57 @@ func analyzeCmd(args []string) {
 		}
 	}
 	sortwkThresh := cfg.Rules.Sortwk.PrimaryCylThreshold
+	ruleSeverity := map[string]string{}
+	ruleParams := map[string]map[string]any{}
+	for id, rc := range cfg.Rules.PerRule {
+		if rc.Severity != "" { ruleSeverity[id] = rc.Severity }
+		if len(rc.Params) > 0 { ruleParams[id] = rc.Params }
+	}
+
+	// Sources: --path replaces analysis.sources and jobs libraries
+	popts, sources, err := parserOptions(cfg, *encoding, *workers)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "analyze:", err)
+		os.Exit(2)
+	}
+	if *inPath != "" {
+		sources = nil
+		for _, p := range strings.Split(*inPath, ",") {
+			if p = strings.TrimSpace(p); p != "" { sources = append(sources, p) }
+		}
+	}
 
 	// I/O prep
-	if *inPath == "" {
+	if len(sources) == 0 {
 		fmt.Fprintln(os.Stderr, "analyze: --path (or analysis.sources in config) is required")
 		os.Exit(2)
 	}
+	if !parser.ValidEncoding(*encoding) {
+		fmt.Fprintf(os.Stderr, "analyze: unknown --encoding %q (auto|ascii|ibm1047|ibm037)\n", *encoding)
+		os.Exit(2)
+	}
 	if err := os.MkdirAll(*outDir, 0o755); err != nil {
 		fmt.Fprintln(os.Stderr, "analyze: cannot create out dir:", err)
 		os.Exit(1)
 	}
 
 	// Configure rules engine
-	rules.SetSettings(rules.Settings{
+	rsettings := rules.Settings{
 		SeverityThreshold:         sth,
 		Disabled:                  disable,
+		RecordDisabled:            cfg.Rules.RecordDisabled,
 		SortwkPrimaryCylThreshold: sortwkThresh,
-	})
+		Workers:                   *workers,
+		Severity:                  ruleSeverity,
+		Params:                    ruleParams,
+	}
+	rules.SetSettings(rsettings)
 
-	// Parse input → build Run
-	run, diags := parser.Parse(*inPath)
-	if len(diags.Warnings) > 0 {
-		slog.Warn("parse warnings", "warnings", diags.Warnings)
+	// Parse input → build Run (PROC/INCLUDE members come from proclibs,
+	// system symbols from config)
+	run, diags := parser.ParseSources(sources, popts)
+	for _, d := range diags.Items {
+		slog.Warn("parse diagnostic", "severity", d.Severity, "code", d.Code, "file", d.File, "line", d.Line, "msg", d.Message)
 	}
 	run.ID = fmt.Sprintf("run-%d", time.Now().Unix())
 	run.StartedAt = time.Now().UTC()
@@ -144,18 +191,30 @@ func analyzeCmd(args []string) {
 		}
 	}
 
-	// Cost annotate (add SizeMB)
-	for i := range run.Jobs {
-		for j := range run.Jobs[i].Steps {
-			size := cost.EstimateSizeMB(&run.Jobs[i].Steps[j], run.Context.Geometry)
-			c := cost.Estimate(&run.Jobs[i].Steps[j], run.Context)
-			run.Jobs[i].Steps[j].Annotations.SizeMB = size
-			run.Jobs[i].Steps[j].Annotations.Cost = c
-		}
+	// Per-rule overrides are checked once DSL rules are registered
+	if err := rules.CheckSettings(rsettings); err != nil {
+		fmt.Fprintln(os.Stderr, "analyze:", err)
+		os.Exit(2)
 	}
+	run.Context.RuleSeverities, run.Context.RuleParams = rules.Effective()
 
-	// Evaluate rules
+	// Dataset lineage across steps and jobs
+	run.Lineage = lineage.Build(&run)
+
+	// Cost annotate (add SizeMB)
+	cost.Annotate(&run, *workers)
+
+	// Evaluate rules (below-threshold findings, and disabled-rule ones
+	// with rules.record_disabled, are kept in run.SuppressedFindings)
 	run.Findings = rules.Evaluate(&run)
+	if n := len(run.SuppressedFindings); n > 0 {
+		slog.Info("findings suppressed", "suppressed", n, "threshold", sth, "reported", len(run.Findings))
+	}
+	for _, st := range run.Context.RuleStats {
+		if st.Panics > 0 {
+			slog.Warn("rule panicked", "rule", st.Rule, "panics", st.Panics, "last", st.LastPanic)
+		}
+	}
 
 	// Persist & report
 	// Persist & report (open DB earlier so we can load waivers)
@@ -170,12 +229,15 @@ func analyzeCmd(args []string) {
 	if len(waivers) > 0 {
 		kept, waived := rules.ApplyWaivers(run.Findings, waivers)
 		run.Findings = kept
-		run.Context.WaivedCount = waived // <-- record how many were waived
-		slog.Info("waivers applied", "waived", waived, "remaining", len(run.Findings))
+		run.SuppressedFindings = append(run.SuppressedFindings, waived...)
+		run.Context.WaivedCount = len(waived) // <-- record how many were waived
+		slog.Info("waivers applied", "waived", len(waived), "remaining", len(run.Findings))
 	}
 
 	// Save run
 	if err := db.SaveRun(&run); err != nil { slog.Error("db save run error", "err", err); os.Exit(1) }
+	// Finding lifecycle (first seen, regressions) for the report's age columns
+	if run.History, err = db.LoadRunHistory(run.ID); err != nil { slog.Warn("finding history error", "err", err) }
 
 	// Reports
 	jsonPath, _ := reporting.WriteJSON(run.ID, *outDir, &run)
@@ -183,11 +245,65 @@ func analyzeCmd(args []string) {
 
 	slog.Info("analyze complete", "run", run.ID, "json", jsonPath, "html", htmlPath, "db", filepath.Clean(*dbPath))
 	fmt.Printf("Analyze OK\n  Run: %s\n  JSON: %s\n  HTML: %s\n  DB: %s\n", run.ID, jsonPath, htmlPath, filepath.Clean(*dbPath))
+	if *profile { printRuleProfile(run.Context.RuleStats) }
 
+	if *failOnParse && diags.Errors() > 0 { os.Exit(4) }
+	// Only reported findings fail the build; suppressed ones never do
 	if *failOn && len(run.Findings) > 0 { os.Exit(3) }
 
 }
 
+// printRuleProfile lists the rules slowest first, with their totals.
+func printRuleProfile(stats []ir.RuleStat) {
+	sorted := append([]ir.RuleStat(nil), stats...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Elapsed > sorted[j].Elapsed })
+
+	var total time.Duration
+	calls, found, panics := 0, 0, 0
+	fmt.Printf("\nRule profile:\n")
+	fmt.Printf("  %-28s %-5s %7s %8s %10s %6s %10s %10s %10s\n", "RULE", "SCOPE", "CALLS", "FINDINGS", "SUPPRESSED", "PANICS", "TOTAL", "MEAN", "MAX")
+	for _, st := range sorted {
+		fmt.Printf("  %-28s %-5s %7d %8d %10d %6d %10s %10s %10s\n", st.Rule, st.Scope, st.Invocations, st.Findings, st.Suppressed, st.Panics,
+			st.Elapsed.Round(time.Microsecond), st.Mean().Round(time.Microsecond), st.Max.Round(time.Microsecond))
+		total += st.Elapsed
+		calls += st.Invocations
+		found += st.Findings
+		panics += st.Panics
+	}
+	fmt.Printf("  %d rules, %d invocations, %d findings, %d panics, %s in rules (summed across workers)\n",
+		len(stats), calls, found, panics, total.Round(time.Microsecond))
+}
+
+// parserOptions builds the parser options and the job sources of cfg:
+// symbols, proclibs and the libraries by role.
+func parserOptions(cfg shared.Config, encoding string, workers int) (parser.Options, []string, error) {
+	popts := parser.Options{
+		Symbols:  cfg.Analysis.Symbols,
+		Encoding: encoding,
+		Include:  cfg.Analysis.Include,
+		Exclude:  cfg.Analysis.Exclude,
+		Workers:  workers,
+	}
+	for _, pl := range cfg.Analysis.ProcLibs {
+		popts.ProcLibs = append(popts.ProcLibs, parser.ProcLib{Dir: pl.Dir, Dataset: pl.Dataset})
+	}
+	sources := cfg.Analysis.Sources
+	for _, l := range cfg.Analysis.Libraries {
+		lib := parser.ProcLib{Dir: l.Dir, Dataset: l.Dataset}
+		switch strings.ToLower(strings.TrimSpace(l.Role)) {
+		case "jobs", "":
+			sources = append(sources, l.Dir)
+		case "procs", "includes":
+			popts.ProcLibs = append(popts.ProcLibs, lib)
+		case "sysin":
+			popts.SysinLibs = append(popts.SysinLibs, lib)
+		default:
+			return popts, nil, fmt.Errorf("library %s has unknown role %q (jobs|procs|includes|sysin)", l.Dir, l.Role)
+		}
+	}
+	return popts, sources, nil
+}
+
 func reportCmd(args []string) {
 	fs := flag.NewFlagSet("report", flag.ExitOnError)
 	configPath := fs.String("config", "", "Path to YAML config (optional)")
@@ -222,6 +338,9 @@ func reportCmd(args []string) {
 		slog.Error("load run error", "err", err)
 		os.Exit(1)
 	}
+	if run.History, err = db.LoadRunHistory(run.ID); err != nil {
+		slog.Warn("finding history error", "err", err)
+	}
 	if err := os.MkdirAll(*outDir, 0o755); err != nil {
 		slog.Error("cannot create out dir", "err", err)
 		os.Exit(1)
@@ -231,6 +350,261 @@ func reportCmd(args []string) {
 	fmt.Printf("Report OK\n  Run: %s\n  JSON: %s\n  HTML: %s\n", run.ID, jsonPath, htmlPath)
 }
 
+func lineageCmd(args []string) {
+	fs := flag.NewFlagSet("lineage", flag.ExitOnError)
+	configPath := fs.String("config", "", "Path to YAML config (optional)")
+	runID := fs.String("run", "", "Run ID (default: latest run)")
+	dataset := fs.String("dataset", "", "Dataset to trace (default: whole graph)")
+	depth := fs.Int("depth", 0, "Datasets to follow up/downstream of --dataset (0 = all)")
+	format := fs.String("format", "text", "Output format: text|json|dot|mermaid")
+	outPath := fs.String("out", "", "Output file (default: stdout)")
+	dbPath := fs.String("db", "", "SQLite database path")
+	_ = fs.Parse(args)
+
+	cfg, _ := shared.LoadConfig(*configPath)
+	shared.InitLogger(cfg.Logging.Format, cfg.Logging.Level)
+
+	if *dbPath == "" {
+		*dbPath = cfg.Database.DSN
+	}
+	db, err := storage.OpenSQLite(*dbPath)
+	if err != nil {
+		slog.Error("db open error", "err", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+	if err := db.CreateSchema(); err != nil {
+		slog.Error("db schema error", "err", err)
+		os.Exit(1)
+	}
+
+	if *runID == "" {
+		rows, err := db.ListRuns(1, 0)
+		if err != nil || len(rows) == 0 {
+			fmt.Fprintln(os.Stderr, "lineage: no runs found; pass --run")
+			os.Exit(2)
+		}
+		*runID = rows[0].ID
+	}
+	g, err := db.LoadLineage(*runID)
+	if err != nil {
+		slog.Error("load lineage error", "err", err, "run", *runID)
+		os.Exit(1)
+	}
+	if *dataset != "" {
+		g = lineage.Trace(g, *dataset, *depth)
+		if len(g.Edges) == 0 {
+			fmt.Fprintf(os.Stderr, "lineage: dataset %s not found in run %s\n", *dataset, *runID)
+			os.Exit(3)
+		}
+	}
+
+	w := os.Stdout
+	if *outPath != "" {
+		f, err := os.Create(*outPath)
+		if err != nil {
+			slog.Error("cannot create output", "err", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := lineage.Write(w, g, *format); err != nil {
+		fmt.Fprintln(os.Stderr, "lineage:", err)
+		os.Exit(2)
+	}
+}
+
+func fixCmd(args []string) {
+	fs := flag.NewFlagSet("fix", flag.ExitOnError)
+	configPath := fs.String("config", "", "Path to YAML config (optional)")
+	runID := fs.String("run", "", "Run ID (default: latest run)")
+	ruleList := fs.String("rule", "", "Comma-separated rule IDs to fix (default: all with fixes)")
+	dryRun := fs.Bool("dry-run", false, "Only print the fixes as a unified diff (what fix does without --apply)")
+	apply := fs.Bool("apply", false, "Rewrite the JCL members in place")
+	outPath := fs.String("out", "", "Diff output file (default: stdout)")
+	dbPath := fs.String("db", "", "SQLite database path")
+	_ = fs.Parse(args)
+	if *dryRun && *apply {
+		fmt.Fprintln(os.Stderr, "fix: --dry-run and --apply are mutually exclusive")
+		os.Exit(2)
+	}
+
+	cfg, _ := shared.LoadConfig(*configPath)
+	shared.InitLogger(cfg.Logging.Format, cfg.Logging.Level)
+
+	if *dbPath == "" {
+		*dbPath = cfg.Database.DSN
+	}
+	db, err := storage.OpenSQLite(*dbPath)
+	if err != nil {
+		slog.Error("db open error", "err", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+	if err := db.CreateSchema(); err != nil {
+		slog.Error("db schema error", "err", err)
+		os.Exit(1)
+	}
+
+	if *runID == "" {
+		rows, err := db.ListRuns(1, 0)
+		if err != nil || len(rows) == 0 {
+			fmt.Fprintln(os.Stderr, "fix: no runs found; pass --run")
+			os.Exit(2)
+		}
+		*runID = rows[0].ID
+	}
+	run, err := db.LoadRun(*runID)
+	if err != nil {
+		slog.Error("load run error", "err", err, "run", *runID)
+		os.Exit(1)
+	}
+
+	// Only reported findings are fixed; waived and suppressed ones are not
+	want := map[string]bool{}
+	for _, id := range strings.Split(*ruleList, ",") {
+		if id = strings.ToUpper(strings.TrimSpace(id)); id != "" {
+			want[id] = true
+		}
+	}
+	var findings []ir.Finding
+	for _, f := range run.Findings {
+		if len(want) == 0 || want[f.RuleID] {
+			findings = append(findings, f)
+		}
+	}
+
+	changes, skipped := fix.Plan(findings)
+	for _, sk := range skipped {
+		slog.Warn("fix skipped", "finding", sk.FindingID, "rule", sk.RuleID, "reason", sk.Reason)
+	}
+	fixed := 0
+	for _, c := range changes {
+		fixed += len(c.Fixed)
+	}
+
+	if *apply {
+		if err := fix.Apply(changes); err != nil {
+			slog.Error("fix apply error", "err", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Fix OK\n  Run: %s\n  Fixed: %d findings in %d members\n  Skipped: %d\n", run.ID, fixed, len(changes), len(skipped))
+		return
+	}
+	w := os.Stdout
+	if *outPath != "" {
+		f, err := os.Create(*outPath)
+		if err != nil {
+			slog.Error("cannot create output", "err", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := fix.WriteDiff(w, changes); err != nil {
+		slog.Error("diff write error", "err", err)
+		os.Exit(1)
+	}
+}
+
+func rulesCmd(args []string) {
+	if len(args) == 0 || args[0] != "test" {
+		fmt.Fprintln(os.Stderr, "rules: usage: jclift rules test <fixtures-dir> [--rules-pack <pack.yaml>]")
+		os.Exit(2)
+	}
+	fs := flag.NewFlagSet("rules test", flag.ExitOnError)
+	configPath := fs.String("config", "", "Path to YAML config (optional)")
+	rulesPack := fs.String("rules-pack", "", "Comma-separated YAML rule packs (DSL) to test")
+	verbose := fs.Bool("v", false, "List every fixture and the findings of its rules")
+	// The directory may come before or after the flags
+	var dir string
+	rest := args[1:]
+	if len(rest) > 0 && !strings.HasPrefix(rest[0], "-") {
+		dir, rest = rest[0], rest[1:]
+	}
+	_ = fs.Parse(rest)
+	if dir == "" {
+		dir = fs.Arg(0)
+	}
+	if dir == "" {
+		fmt.Fprintln(os.Stderr, "rules test: fixtures directory is required")
+		os.Exit(2)
+	}
+
+	cfg, _ := shared.LoadConfig(*configPath)
+	shared.InitLogger(cfg.Logging.Format, cfg.Logging.Level)
+
+	// Rule parameters and severities as analyze would run them
+	rsettings := rules.Settings{
+		SeverityThreshold:         cfg.Rules.SeverityThreshold,
+		SortwkPrimaryCylThreshold: cfg.Rules.Sortwk.PrimaryCylThreshold,
+		Severity:                  map[string]string{},
+		Params:                    map[string]map[string]any{},
+	}
+	for id, rc := range cfg.Rules.PerRule {
+		if rc.Severity != "" {
+			rsettings.Severity[id] = rc.Severity
+		}
+		if len(rc.Params) > 0 {
+			rsettings.Params[id] = rc.Params
+		}
+	}
+	rules.SetSettings(rsettings)
+	for _, p := range strings.Split(*rulesPack, ",") {
+		if p = strings.TrimSpace(p); p == "" {
+			continue
+		}
+		if _, err := rulesdsl.LoadAndRegister(p); err != nil {
+			fmt.Fprintf(os.Stderr, "rules test: rule pack %s: %v\n", p, err)
+			os.Exit(2)
+		}
+	}
+	if err := rules.CheckSettings(rsettings); err != nil {
+		fmt.Fprintln(os.Stderr, "rules test:", err)
+		os.Exit(2)
+	}
+
+	// Proclibs, SYSIN libraries and symbols as analyze would use them; the
+	// fixtures are named explicitly, so the discovery globs do not apply
+	popts, _, err := parserOptions(cfg, cfg.Analysis.Encoding, cfg.Analysis.Workers)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "rules test:", err)
+		os.Exit(2)
+	}
+	popts.Include, popts.Exclude = nil, nil
+	ctx := rulestest.ContextFrom(cfg)
+	opts := rulestest.Options{Parser: popts, Context: &ctx}
+	results, err := rulestest.RunDir(dir, opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "rules test:", err)
+		os.Exit(2)
+	}
+	if len(results) == 0 {
+		fmt.Fprintf(os.Stderr, "rules test: no %s fixtures under %s\n", rulestest.FixtureExt, dir)
+		os.Exit(2)
+	}
+
+	failed := 0
+	for _, r := range results {
+		if !r.OK() {
+			failed++
+			fmt.Printf("FAIL %s\n", r.Fixture.File)
+			for _, m := range r.Mismatches {
+				fmt.Printf("    %s\n", m)
+			}
+			continue
+		}
+		if *verbose {
+			fmt.Printf("ok   %s (%s: %d findings)\n", r.Fixture.File, strings.Join(r.Fixture.Rules, ","), len(r.Findings))
+		}
+	}
+	fmt.Printf("%d fixtures, %d failed\n", len(results), failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
 func diffCmd(args []string) {
 	fs := flag.NewFlagSet("diff", flag.ExitOnError)
 	configPath := fs.String("config", "", "Path to YAML config (optional)")
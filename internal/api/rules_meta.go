@@ -8,17 +8,20 @@ import (
 
 func (s *Server) handleRulesMeta(w http.ResponseWriter, r *http.Request) {
 	type R struct {
-		ID              string `json:"id"`
-		Summary         string `json:"summary"`
-		Type            string `json:"type"`
-		DefaultSeverity string `json:"default_severity"`
-		Docs            string `json:"docs,omitempty"`
+		ID              string        `json:"id"`
+		Summary         string        `json:"summary"`
+		Type            string        `json:"type"`
+		DefaultSeverity string        `json:"default_severity"`
+		Scope           string        `json:"scope"` // job|run
+		Docs            string        `json:"docs,omitempty"`
+		Params          []rules.Param `json:"params,omitempty"`
 	}
 	var out []R
 	for _, rr := range rules.List() {
 		out = append(out, R{
 			ID: rr.ID, Summary: rr.Summary, Type: rr.Type,
-			DefaultSeverity: rr.DefaultSeverity, Docs: rr.Docs,
+			DefaultSeverity: rr.DefaultSeverity, Scope: rr.Scope(), Docs: rr.Docs,
+			Params: rr.Params,
 		})
 	}
 	writeJSON(w, http.StatusOK, map[string]any{"items": out, "count": len(out)})
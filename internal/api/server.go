@@ -1,14 +1,18 @@
 package api
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/lineage"
 	"github.com/codewithboateng/jclift/internal/rules"
 	"github.com/codewithboateng/jclift/internal/storage"
 )
@@ -17,14 +21,19 @@ import (
 type Store interface {
 	ListRuns(limit, offset int) ([]storage.RunRow, error)
 	LoadRun(id string) (ir.Run, error)
+	HasRun(id string) (bool, error)
 	ListFindings(runID, minSeverity string) ([]ir.Finding, error)
+	LoadLineage(runID string) (*ir.Lineage, error)
+	LoadFindingHistory(source, fingerprint string) (ir.FindingHistory, error)
+	ListFindingHistory(fingerprint string) ([]ir.FindingHistory, error)
+	ListFindingOccurrences(source, fingerprint string) ([]storage.FindingOccurrence, error)
 
 	// NEW
 	LoadLatestRun() (ir.Run, error)
 
 	// Waivers (from previous commit)
 	ListWaivers(activeOnly bool) ([]storage.Waiver, error)
-	CreateWaiver(ruleID, job, step, pattern, reason, createdBy string, expires time.Time) (int64, error)
+	CreateWaiver(ruleID, job, step, pattern, fingerprint, reason, createdBy string, expires time.Time) (int64, error)
 	RevokeWaiver(id int64, by string) error
 }
 
@@ -80,6 +89,9 @@ func (s *Server) Routes() http.Handler {
 	mux.HandleFunc("GET /api/v1/runs/latest", withCORS(s.handleGetLatest))
 	mux.HandleFunc("GET /api/v1/runs/{id}", withCORS(s.handleGetRun))
 	mux.HandleFunc("GET /api/v1/runs/{id}/findings", withCORS(s.handleListFindings))
+	mux.HandleFunc("GET /api/v1/runs/{id}/lineage", withCORS(s.handleLineage))
+	mux.HandleFunc("GET /api/v1/runs/{id}/stats", withCORS(s.handleRunStats))
+	mux.HandleFunc("GET /api/v1/findings/{fingerprint}/history", withCORS(s.handleFindingHistory))
 
 	// Rules inventory
 	mux.HandleFunc("GET /api/v1/rules", withCORS(s.handleRules))
@@ -177,6 +189,125 @@ func (s *Server) handleListFindings(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleLineage returns a run's dataset lineage, optionally traced around
+// ?dataset= (limited by ?depth=), as JSON or, with ?format=dot|mermaid, text.
+func (s *Server) handleLineage(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	q := r.URL.Query()
+	ok, err := s.DB.HasRun(id)
+	if err != nil {
+		s.err(w, http.StatusInternalServerError, "db error: "+err.Error())
+		return
+	}
+	if !ok {
+		s.err(w, http.StatusNotFound, "run not found")
+		return
+	}
+	g, err := s.DB.LoadLineage(id)
+	if err != nil {
+		s.err(w, http.StatusInternalServerError, "db error: "+err.Error())
+		return
+	}
+	if ds := strings.TrimSpace(q.Get("dataset")); ds != "" {
+		g = lineage.Trace(g, ds, parseInt(q.Get("depth"), 0))
+	}
+	switch format := strings.ToLower(q.Get("format")); format {
+	case "", lineage.FormatJSON:
+		writeJSON(w, http.StatusOK, map[string]any{
+			"run_id": id, "dataset": q.Get("dataset"), "datasets": g.Datasets, "edges": g.Edges,
+		})
+	case lineage.FormatDOT, lineage.FormatMermaid:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_ = lineage.Write(w, g, format)
+	default:
+		s.err(w, http.StatusBadRequest, "format must be json, dot or mermaid")
+	}
+}
+
+// handleRunStats returns a run's per-rule evaluation stats, by rule ID or,
+// with ?sort=elapsed|findings|panics, largest first, with their totals.
+func (s *Server) handleRunStats(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	run, err := s.DB.LoadRun(id)
+	if err != nil {
+		s.err(w, http.StatusNotFound, "run not found")
+		return
+	}
+	stats := run.Context.RuleStats
+	if stats == nil {
+		stats = []ir.RuleStat{} // run predates rule stats
+	}
+	var key func(ir.RuleStat) int64
+	switch by := strings.ToLower(r.URL.Query().Get("sort")); by {
+	case "", "rule":
+	case "elapsed":
+		key = func(st ir.RuleStat) int64 { return int64(st.Elapsed) }
+	case "findings":
+		key = func(st ir.RuleStat) int64 { return int64(st.Findings) }
+	case "panics":
+		key = func(st ir.RuleStat) int64 { return int64(st.Panics) }
+	default:
+		s.err(w, http.StatusBadRequest, "sort must be rule, elapsed, findings or panics")
+		return
+	}
+	if key != nil {
+		sort.SliceStable(stats, func(i, j int) bool { return key(stats[i]) > key(stats[j]) })
+	}
+
+	var total ir.RuleStat
+	for _, st := range stats {
+		total.Invocations += st.Invocations
+		total.Findings += st.Findings
+		total.Suppressed += st.Suppressed
+		total.Panics += st.Panics
+		total.Elapsed += st.Elapsed
+		total.Max = max(total.Max, st.Max)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"run_id": id,
+		"rules":  stats,
+		"totals": map[string]any{
+			"rules": len(stats), "invocations": total.Invocations, "findings": total.Findings,
+			"suppressed": total.Suppressed, "panics": total.Panics,
+			"elapsed_ns": total.Elapsed, "max_ns": total.Max,
+		},
+	})
+}
+
+func (s *Server) handleFindingHistory(w http.ResponseWriter, r *http.Request) {
+	fp := r.PathValue("fingerprint")
+	// History is kept per source; without ?source= the one that saw the
+	// finding last.
+	var h ir.FindingHistory
+	var err error
+	if q := r.URL.Query(); q.Has("source") {
+		h, err = s.DB.LoadFindingHistory(q.Get("source"), fp)
+	} else {
+		var hs []ir.FindingHistory
+		if hs, err = s.DB.ListFindingHistory(fp); err == nil && len(hs) == 0 {
+			err = sql.ErrNoRows
+		} else if err == nil {
+			h = hs[0]
+		}
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		s.err(w, http.StatusNotFound, "finding not found")
+		return
+	}
+	if err != nil {
+		s.err(w, http.StatusInternalServerError, "db error: "+err.Error())
+		return
+	}
+	occ, err := s.DB.ListFindingOccurrences(h.Source, fp)
+	if err != nil {
+		s.err(w, http.StatusInternalServerError, "db error: "+err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"history": h, "status": h.Status(), "occurrences": occ,
+	})
+}
+
 func (s *Server) handleListRules(w http.ResponseWriter, r *http.Request) {
 	type rr struct {
 		ID      string `json:"id"`
@@ -12,6 +12,7 @@ type waiverCreateReq struct {
 	Job        string `json:"job,omitempty"`
 	Step       string `json:"step,omitempty"`
 	PatternSub string `json:"pattern_sub,omitempty"`
+	Fingerprint string `json:"fingerprint,omitempty"`
 	Reason     string `json:"reason"`
 	ExpiresAt  string `json:"expires_at"` // ISO8601
 }
@@ -44,7 +45,7 @@ func (s *Server) handleCreateWaiver(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	u, ok := userFromCtx(r.Context()); if !ok { s.err(w, http.StatusUnauthorized, "unauthorized"); return }
-	id, err := s.DB.CreateWaiver(in.RuleID, in.Job, in.Step, in.PatternSub, in.Reason, u.Username, exp)
+	id, err := s.DB.CreateWaiver(in.RuleID, in.Job, in.Step, in.PatternSub, in.Fingerprint, in.Reason, u.Username, exp)
 	if err != nil {
 		s.err(w, http.StatusInternalServerError, "db error: "+err.Error()); return
 	}
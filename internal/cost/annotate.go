@@ -0,0 +1,18 @@
+package cost
+
+import (
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/shared"
+)
+
+// Annotate fills SizeMB and Cost on every step of run, spreading jobs over
+// workers goroutines (GOMAXPROCS when workers <= 0).
+func Annotate(run *ir.Run, workers int) {
+	shared.ParallelFor(len(run.Jobs), workers, func(i int) {
+		for j := range run.Jobs[i].Steps {
+			st := &run.Jobs[i].Steps[j]
+			st.Annotations.SizeMB = EstimateSizeMB(st, run.Context.Geometry)
+			st.Annotations.Cost = Estimate(st, run.Context)
+		}
+	})
+}
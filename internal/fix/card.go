@@ -0,0 +1,277 @@
+package fix
+
+import (
+	"fmt"
+	"strings"
+)
+
+// JCL card layout: columns 1-71 hold the statement, columns 72-80 the
+// continuation indicator and sequence numbers, which edits leave alone.
+const (
+	stmtCols  = 71
+	maxIndent = 15 // continued operands resume by column 16
+)
+
+// splitCard separates the statement columns of a card from columns 72-80.
+func splitCard(card string) (text, tail string) {
+	if len(card) <= stmtCols {
+		return card, ""
+	}
+	return card[:stmtCols], card[stmtCols:]
+}
+
+func isComment(card string) bool { return strings.HasPrefix(card, "//*") }
+
+// isJES2 reports whether a record is a JES2 control statement (/*JOBPARM,
+// /*ROUTE, ...) rather than an in-stream data delimiter.
+func isJES2(line string) bool {
+	return len(line) > 2 && line[0] == '/' && line[1] == '*' && line[2] >= 'A' && line[2] <= 'Z'
+}
+
+// nameOp returns the name and operation fields of a statement's first card.
+func nameOp(card string) (name, op string) {
+	text, _ := splitCard(card)
+	if !strings.HasPrefix(text, "//") || isComment(text) {
+		return "", ""
+	}
+	rest := text[2:]
+	if i := strings.IndexByte(rest, ' '); i >= 0 {
+		name, rest = rest[:i], rest[i:]
+	} else {
+		return rest, ""
+	}
+	fields := strings.Fields(rest)
+	if len(fields) > 0 {
+		op = fields[0]
+	}
+	return name, op
+}
+
+// operandStart returns the index of the operand field of a card: past the
+// name and operation on a statement's first card, past the leading blanks
+// on a continuation card. -1 when the card has no operands.
+func operandStart(text string, first bool) int {
+	i := 2
+	if first {
+		for i < len(text) && text[i] != ' ' {
+			i++
+		}
+		for i < len(text) && text[i] == ' ' {
+			i++
+		}
+		for i < len(text) && text[i] != ' ' {
+			i++
+		}
+	}
+	for i < len(text) && text[i] == ' ' {
+		i++
+	}
+	if i >= len(text) {
+		return -1
+	}
+	return i
+}
+
+// operandEnd returns where the operand field starting at start ends: the
+// first blank outside quotes. inQuote reports a quoted string continued on
+// the next card.
+func operandEnd(text string, start int) (end int, inQuote bool) {
+	for i := start; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '\'':
+			inQuote = !inQuote
+		case c == ' ' && !inQuote:
+			return i, false
+		}
+	}
+	return len(text), inQuote
+}
+
+// statementEnd returns the index after the last card of the statement
+// starting at lines[i]; comment cards between continuations belong to it.
+func statementEnd(lines []string, i int) int {
+	first := true
+	for i < len(lines) {
+		text, _ := splitCard(lines[i])
+		start := operandStart(text, first)
+		if start < 0 {
+			return i + 1
+		}
+		end, inQuote := operandEnd(text, start)
+		if !inQuote && !strings.HasSuffix(text[:end], ",") {
+			return i + 1
+		}
+		// The next card that is not a comment continues the statement.
+		next := i + 1
+		for next < len(lines) && isComment(lines[next]) {
+			next++
+		}
+		if next >= len(lines) || !strings.HasPrefix(lines[next], "// ") {
+			return i + 1
+		}
+		i, first = next, false
+	}
+	return i
+}
+
+// setParam sets keyword param to value on the statement cards, replacing
+// the value as coded or appending the keyword after the last operand.
+func setParam(cards []string, param, value string) ([]string, error) {
+	key := strings.ToUpper(param) + "="
+	depth := 0
+	last := -1 // last card with operands
+	for ci, card := range cards {
+		if isComment(card) {
+			continue
+		}
+		text, _ := splitCard(card)
+		start := operandStart(text, ci == 0)
+		if start < 0 {
+			continue
+		}
+		last = ci
+		end, inQuote := operandEnd(text, start)
+		if inQuote {
+			return nil, fmt.Errorf("quoted string continued on line %d", ci+1)
+		}
+		atOperand := depth == 0
+		for i := start; i < end; i++ {
+			if atOperand && depth == 0 && strings.HasPrefix(strings.ToUpper(text[i:end]), key) {
+				vs := i + len(key)
+				ve, ok := valueEnd(text[:end], vs)
+				if !ok {
+					return nil, fmt.Errorf("%s%s is continued on the next card", key, text[vs:end])
+				}
+				out, err := rewrite(text, card, start, end, text[:vs]+value+text[ve:end])
+				if err != nil {
+					return nil, err
+				}
+				return splice(cards, ci, out), nil
+			}
+			atOperand = false
+			switch text[i] {
+			case '\'':
+				if q := strings.IndexByte(text[i+1:end], '\''); q >= 0 {
+					i += q + 1
+				}
+			case '(':
+				depth++
+			case ')':
+				depth--
+			case ',':
+				atOperand = depth == 0
+			}
+		}
+	}
+	if last < 0 {
+		return nil, fmt.Errorf("statement has no operands")
+	}
+	text, _ := splitCard(cards[last])
+	start := operandStart(text, last == 0)
+	end, _ := operandEnd(text, start)
+	out, err := rewrite(text, cards[last], start, end, text[:end]+","+key+value)
+	if err != nil {
+		return nil, err
+	}
+	return splice(cards, last, out), nil
+}
+
+// valueEnd returns the end of a keyword value starting at vs: the next
+// operand comma or the end of the field. ok is false when parentheses
+// stay open, i.e. the value continues on the next card.
+func valueEnd(field string, vs int) (int, bool) {
+	depth, inQuote := 0, false
+	for i := vs; i < len(field); i++ {
+		switch c := field[i]; {
+		case c == '\'':
+			inQuote = !inQuote
+		case inQuote:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			return i, true
+		}
+	}
+	return len(field), depth == 0
+}
+
+// rewrite replaces the operand field of a card, text[:end] (start is where
+// the operands begin), with operands. A comment after the operands keeps
+// its column while there is room; a card that no longer fits in columns
+// 1-71 is split at an operand comma onto a continuation card.
+func rewrite(text, card string, start, end int, operands string) ([]string, error) {
+	_, tail := splitCard(card)
+	after := text[end:]
+	comment := strings.TrimSpace(after)
+	line := operands
+	if comment != "" {
+		gap := strings.Index(after, comment) - (len(operands) - end)
+		if gap < 1 {
+			gap = 1
+		}
+		line += strings.Repeat(" ", gap) + comment
+	}
+	if len(line) <= stmtCols {
+		return []string{pad(line, len(text), tail)}, nil
+	}
+
+	indent := start
+	if indent > maxIndent {
+		indent = maxIndent
+	}
+	for _, c := range operandCommas(operands, start) {
+		head, rest := operands[:c+1], "//"+strings.Repeat(" ", indent-2)+operands[c+1:]
+		if len(head) > stmtCols {
+			continue
+		}
+		if comment != "" && len(rest)+1+len(comment) <= stmtCols {
+			rest += " " + comment
+		}
+		if len(rest) <= stmtCols {
+			return []string{pad(head, len(text), tail), rest}, nil
+		}
+	}
+	return nil, fmt.Errorf("operands do not fit in columns 1-%d", stmtCols)
+}
+
+// operandCommas returns the positions of the commas separating operands,
+// last first.
+func operandCommas(operands string, start int) []int {
+	var out []int
+	depth, inQuote := 0, false
+	for i := start; i < len(operands); i++ {
+		switch c := operands[i]; {
+		case c == '\'':
+			inQuote = !inQuote
+		case inQuote:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			out = append([]int{i}, out...)
+		}
+	}
+	return out
+}
+
+// pad restores the card's width (trailing blanks, columns 72-80).
+func pad(line string, width int, tail string) string {
+	if tail != "" {
+		width = stmtCols
+	}
+	if len(line) < width {
+		line += strings.Repeat(" ", width-len(line))
+	}
+	return line + tail
+}
+
+// splice replaces cards[i] with repl.
+func splice(cards []string, i int, repl []string) []string {
+	out := make([]string, 0, len(cards)+len(repl)-1)
+	out = append(out, cards[:i]...)
+	out = append(out, repl...)
+	return append(out, cards[i+1:]...)
+}
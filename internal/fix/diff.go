@@ -0,0 +1,72 @@
+package fix
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+const contextLines = 3
+
+// WriteDiff writes the changes as a unified diff, for review or
+// `patch -p0`.
+func WriteDiff(w io.Writer, changes []Change) error {
+	bw := bufio.NewWriter(w)
+	for _, c := range changes {
+		fmt.Fprintf(bw, "--- %s\n+++ %s\n", c.File, c.File)
+		offset := 0 // new line number minus old line number so far
+		for i := 0; i < len(c.hunks); {
+			// Regions whose context overlaps share a hunk.
+			j := i + 1
+			for j < len(c.hunks) && c.hunks[j].start-c.hunks[j-1].end <= 2*contextLines {
+				j++
+			}
+			start := max(c.hunks[i].start-contextLines, 0)
+			end := min(c.hunks[j-1].end+contextLines, len(c.Old))
+
+			var body []string
+			oldN, newN := 0, 0
+			at := start
+			hunkOffset := offset
+			for _, r := range c.hunks[i:j] {
+				for ; at < r.start; at++ {
+					body = append(body, " "+c.Old[at])
+					oldN++
+					newN++
+				}
+				for ; at < r.end; at++ {
+					body = append(body, "-"+c.Old[at])
+					oldN++
+				}
+				for _, l := range r.lines {
+					body = append(body, "+"+l)
+					newN++
+				}
+				offset += len(r.lines) - (r.end - r.start)
+			}
+			for ; at < end; at++ {
+				body = append(body, " "+c.Old[at])
+				oldN++
+				newN++
+			}
+
+			fmt.Fprintf(bw, "@@ -%s +%s @@\n", hunkRange(start, oldN), hunkRange(start+hunkOffset, newN))
+			for _, l := range body {
+				fmt.Fprintln(bw, l)
+			}
+			i = j
+		}
+	}
+	return bw.Flush()
+}
+
+// hunkRange renders the line range of a hunk side; start is 0-based.
+func hunkRange(start, n int) string {
+	if n == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if n == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, n)
+}
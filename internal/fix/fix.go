@@ -0,0 +1,284 @@
+// Package fix turns the suggested fixes of findings into rewritten JCL
+// members: rendered as a unified diff, or applied in place.
+package fix
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+// Change is the rewrite of one member.
+type Change struct {
+	File     string
+	Old, New []string // lines without line ends
+	Fixed    []string // IDs of the findings whose fixes it carries
+
+	eol     string // line end of the member
+	hunks   []region
+	trailer bool // member ends with a line end
+}
+
+// Skipped is a finding whose fix was not applied, with why.
+type Skipped struct {
+	FindingID string `json:"finding_id"`
+	RuleID    string `json:"rule_id"`
+	Reason    string `json:"reason"`
+}
+
+// region replaces lines [start, end) of a member with lines.
+type region struct {
+	start, end int
+	lines      []string
+}
+
+// member is a file being edited: removed lines and rewritten statements,
+// keyed by their first line, in terms of the original lines.
+type member struct {
+	lines   []string
+	deleted []bool
+	stmts   map[int]region
+}
+
+func (m *member) clone() *member {
+	c := &member{lines: m.lines, deleted: append([]bool(nil), m.deleted...), stmts: map[int]region{}}
+	for k, v := range m.stmts {
+		c.stmts[k] = v
+	}
+	return c
+}
+
+// Plan computes the member rewrites for the fixes of findings. Findings
+// without a fix are ignored. A finding whose edits cannot all be made (the
+// member is unreadable or changed since the run, or another fix removed
+// the statement) is skipped as a whole. Step removals are planned first so
+// they win over edits to the statements they remove.
+func Plan(findings []ir.Finding) ([]Change, []Skipped) {
+	var todo []ir.Finding
+	for _, f := range findings {
+		if f.Fix != nil && len(f.Fix.Edits) > 0 {
+			todo = append(todo, f)
+		}
+	}
+	sort.SliceStable(todo, func(i, j int) bool { return deletes(todo[i]) && !deletes(todo[j]) })
+
+	members := map[string]*member{}
+	meta := map[string]*Change{}
+	var skipped []Skipped
+	var order []string
+	for _, f := range todo {
+		skip := func(reason string) {
+			skipped = append(skipped, Skipped{FindingID: f.ID, RuleID: f.RuleID, Reason: reason})
+		}
+		trial := map[string]*member{}
+		var err error
+		for _, e := range f.Fix.Edits {
+			if e.Loc == nil || e.Loc.File == "" || e.Loc.Line < 1 {
+				err = fmt.Errorf("edit has no source location")
+				break
+			}
+			m := trial[e.Loc.File]
+			if m == nil {
+				base, ok := members[e.Loc.File]
+				if !ok {
+					c, lines, rerr := read(e.Loc.File)
+					if rerr != nil {
+						err = rerr
+						break
+					}
+					base = &member{lines: lines, deleted: make([]bool, len(lines)), stmts: map[int]region{}}
+					members[e.Loc.File], meta[e.Loc.File] = base, c
+					order = append(order, e.Loc.File)
+				}
+				m = base.clone()
+				trial[e.Loc.File] = m
+			}
+			if err = m.apply(e); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			skip(err.Error())
+			continue
+		}
+		for file, m := range trial {
+			members[file] = m
+			meta[file].Fixed = append(meta[file].Fixed, f.ID)
+		}
+	}
+
+	var out []Change
+	sort.Strings(order)
+	for _, file := range order {
+		c := meta[file]
+		if len(c.Fixed) == 0 {
+			continue
+		}
+		members[file].render(c)
+		out = append(out, *c)
+	}
+	return out, skipped
+}
+
+func deletes(f ir.Finding) bool {
+	for _, e := range f.Fix.Edits {
+		if e.Op == ir.EditDelete {
+			return true
+		}
+	}
+	return false
+}
+
+// read loads a member as lines, remembering its line ends.
+func read(file string) (*Change, []string, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read member: %w", err)
+	}
+	if bytes.IndexByte(b, 0) >= 0 {
+		return nil, nil, fmt.Errorf("%s is not a text member", file)
+	}
+	c := &Change{File: file, eol: "\n"}
+	s := string(b)
+	if strings.Contains(s, "\r\n") {
+		c.eol = "\r\n"
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	if strings.HasSuffix(s, "\n") {
+		c.trailer = true
+		s = s[:len(s)-1]
+	}
+	lines := strings.Split(s, "\n")
+	return c, lines, nil
+}
+
+// apply makes one edit, checking the statement it targets first.
+func (m *member) apply(e ir.Edit) error {
+	i := e.Loc.Line - 1
+	if i >= len(m.lines) {
+		return fmt.Errorf("line %d is past the end of the member", e.Loc.Line)
+	}
+	if m.deleted[i] {
+		return fmt.Errorf("statement on line %d is removed by another fix", e.Loc.Line)
+	}
+	cards := m.lines[i:statementEnd(m.lines, i)]
+	if r, ok := m.stmts[i]; ok {
+		cards = r.lines
+	}
+	name, op := nameOp(cards[0])
+	if !strings.EqualFold(op, e.Stmt) || !strings.EqualFold(name, e.Name) {
+		return fmt.Errorf("line %d is no longer the %s statement %s; re-run analyze", e.Loc.Line, e.Stmt, e.Name)
+	}
+
+	switch e.Op {
+	case ir.EditDelete:
+		end := e.Loc.EndLine
+		if end < e.Loc.Line {
+			end = e.Loc.Line
+		}
+		if end > len(m.lines) {
+			return fmt.Errorf("line %d is past the end of the member", end)
+		}
+		// In-stream data and its delimiter go with the statement before them.
+		for end < len(m.lines) && !strings.HasPrefix(m.lines[end], "//") && !isJES2(m.lines[end]) {
+			end++
+		}
+		for k := i; k < end; k++ {
+			if _, ok := m.stmts[k]; ok && k != i {
+				return fmt.Errorf("line %d is rewritten by another fix", k+1)
+			}
+		}
+		delete(m.stmts, i)
+		for k := i; k < end; k++ {
+			m.deleted[k] = true
+		}
+	case ir.EditSet:
+		out, err := setParam(cards, e.Param, e.Value)
+		if err != nil {
+			return fmt.Errorf("cannot set %s on line %d: %w", e.Param, e.Loc.Line, err)
+		}
+		m.stmts[i] = region{start: i, end: statementEnd(m.lines, i), lines: out}
+	default:
+		return fmt.Errorf("unknown edit %q", e.Op)
+	}
+	return nil
+}
+
+// render fills in the new lines of c and the regions that changed.
+func (m *member) render(c *Change) {
+	c.Old = m.lines
+	c.New = nil
+	c.hunks = nil
+	for i := 0; i < len(m.lines); {
+		switch r, ok := m.stmts[i]; {
+		case ok:
+			c.New = append(c.New, r.lines...)
+			c.hunks = append(c.hunks, changed(m.lines, r))
+			i = r.end
+		case m.deleted[i]:
+			start := i
+			for i < len(m.lines) && m.deleted[i] {
+				i++
+			}
+			c.hunks = append(c.hunks, region{start: start, end: i})
+		default:
+			c.New = append(c.New, m.lines[i])
+			i++
+		}
+	}
+}
+
+// changed narrows a rewritten statement to the cards that differ.
+func changed(lines []string, r region) region {
+	old := lines[r.start:r.end]
+	p := 0
+	for p < len(old) && p < len(r.lines) && old[p] == r.lines[p] {
+		p++
+	}
+	s := 0
+	for s < len(old)-p && s < len(r.lines)-p && old[len(old)-1-s] == r.lines[len(r.lines)-1-s] {
+		s++
+	}
+	return region{start: r.start + p, end: r.end - s, lines: r.lines[p : len(r.lines)-s]}
+}
+
+// Apply rewrites the members in place, keeping their line ends and mode.
+func Apply(changes []Change) error {
+	for _, c := range changes {
+		info, err := os.Stat(c.File)
+		if err != nil {
+			return err
+		}
+		body := strings.Join(c.New, c.eol)
+		if c.trailer && len(c.New) > 0 {
+			body += c.eol
+		}
+		tmp, err := os.CreateTemp(filepath.Dir(c.File), ".jclift-fix-*")
+		if err != nil {
+			return err
+		}
+		if _, err := tmp.WriteString(body); err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+			return err
+		}
+		if err := tmp.Close(); err != nil {
+			os.Remove(tmp.Name())
+			return err
+		}
+		if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+			os.Remove(tmp.Name())
+			return err
+		}
+		if err := os.Rename(tmp.Name(), c.File); err != nil {
+			os.Remove(tmp.Name())
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package ir
+
+import "strings"
+
+// StepCond is the parsed EXEC COND= parameter. The step is bypassed when any
+// test is true; EVEN/ONLY control whether it runs after an abend.
+type StepCond struct {
+	Tests []CondTest `json:"tests,omitempty"`
+	Even  bool       `json:"even,omitempty"`
+	Only  bool       `json:"only,omitempty"`
+}
+
+// CondTest is one (code,operator[,stepname[.procstepname]]) COND test.
+// Without a step it applies to every previous step.
+type CondTest struct {
+	Code     int    `json:"code"`
+	Operator string `json:"operator"` // GT|GE|EQ|LT|LE|NE
+	Step     string `json:"step,omitempty"`
+	ProcStep string `json:"proc_step,omitempty"`
+}
+
+// IfClause places a step inside one IF/THEN/ELSE/ENDIF construct.
+type IfClause struct {
+	ID   int       `json:"id"`             // ordinal of the IF within the job
+	Name string    `json:"name,omitempty"` // IF statement label
+	Text string    `json:"text"`           // expression as coded
+	Expr *CondExpr `json:"expr,omitempty"` // nil when the expression could not be parsed
+	Else bool      `json:"else,omitempty"` // step sits in the ELSE branch
+}
+
+// CondExpr is a node of an IF relational expression. AND/OR nodes use Left
+// and Right, NOT uses Left, and REL nodes compare a keyword to a value.
+type CondExpr struct {
+	Op       string    `json:"op"` // AND|OR|NOT|REL
+	Left     *CondExpr `json:"left,omitempty"`
+	Right    *CondExpr `json:"right,omitempty"`
+	Keyword  string    `json:"keyword,omitempty"` // RC|ABEND|ABENDCC|RUN
+	Step     string    `json:"step,omitempty"`    // empty: any/all previous steps
+	ProcStep string    `json:"proc_step,omitempty"`
+	Relation string    `json:"relation,omitempty"` // GT|GE|EQ|LT|LE|NE
+	Value    string    `json:"value,omitempty"`    // number, TRUE/FALSE, Sxxx/Uxxxx
+}
+
+// MayRunAfterAbend reports whether the step can still execute once step (a
+// name as recorded in Step.Name, e.g. S1 or JSTEP.PSTEP) has abended. After
+// an abend the system bypasses later steps unless they carry COND=EVEN/ONLY
+// or sit in an IF construct that tests for the abend; the conditions are then
+// evaluated with every other step's outcome treated as unknown.
+func (s Step) MayRunAfterAbend(step string) bool {
+	eligible := s.Cond != nil && (s.Cond.Even || s.Cond.Only)
+	for _, c := range s.When {
+		if c.Expr == nil || c.Expr.testsAbend() {
+			eligible = true
+		}
+	}
+	if !eligible {
+		return false
+	}
+	for _, c := range s.When {
+		if c.Expr == nil {
+			continue
+		}
+		v := c.Expr.evalAfterAbend(step)
+		if c.Else {
+			v = v.not()
+		}
+		if v == triFalse {
+			return false
+		}
+	}
+	return true
+}
+
+type tri int
+
+const (
+	triUnknown tri = iota
+	triTrue
+	triFalse
+)
+
+func (t tri) not() tri {
+	switch t {
+	case triTrue:
+		return triFalse
+	case triFalse:
+		return triTrue
+	}
+	return triUnknown
+}
+
+func (e *CondExpr) testsAbend() bool {
+	if e == nil {
+		return false
+	}
+	switch e.Op {
+	case "REL":
+		return e.Keyword == "ABEND" || e.Keyword == "ABENDCC" ||
+			(e.Keyword == "RUN" && isFalse(e.Relation, e.Value))
+	case "NOT":
+		if e.Left != nil && e.Left.Op == "REL" && e.Left.Keyword == "RUN" {
+			return !isFalse(e.Left.Relation, e.Left.Value)
+		}
+	}
+	return e.Left.testsAbend() || e.Right.testsAbend()
+}
+
+// evalAfterAbend evaluates the expression knowing only that step abended.
+func (e *CondExpr) evalAfterAbend(step string) tri {
+	switch e.Op {
+	case "NOT":
+		return e.Left.evalAfterAbend(step).not()
+	case "AND":
+		l, r := e.Left.evalAfterAbend(step), e.Right.evalAfterAbend(step)
+		if l == triFalse || r == triFalse {
+			return triFalse
+		}
+		if l == triTrue && r == triTrue {
+			return triTrue
+		}
+		return triUnknown
+	case "OR":
+		l, r := e.Left.evalAfterAbend(step), e.Right.evalAfterAbend(step)
+		if l == triTrue || r == triTrue {
+			return triTrue
+		}
+		if l == triFalse && r == triFalse {
+			return triFalse
+		}
+		return triUnknown
+	}
+
+	self := e.Step == "" || strings.EqualFold(e.ref(), step)
+	var v tri
+	switch e.Keyword {
+	case "ABEND":
+		v = triFalse
+		if self {
+			v = triTrue
+		}
+	case "RUN":
+		if e.Step != "" && self {
+			v = triTrue
+		}
+	case "ABENDCC":
+		if !self {
+			v = triFalse
+		}
+	}
+	if v != triUnknown && e.Keyword != "ABENDCC" && isFalse(e.Relation, e.Value) {
+		v = v.not()
+	}
+	return v
+}
+
+func (e *CondExpr) ref() string {
+	if e.ProcStep != "" {
+		return e.Step + "." + e.ProcStep
+	}
+	return e.Step
+}
+
+// isFalse reports whether a boolean test asks for FALSE (ABEND=FALSE,
+// RUN¬=TRUE, ...).
+func isFalse(relation, value string) bool {
+	f := strings.EqualFold(value, "FALSE")
+	if relation == "NE" {
+		return !f
+	}
+	return f
+}
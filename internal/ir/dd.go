@@ -0,0 +1,104 @@
+package ir
+
+// Disposition is the parsed DISP=(status,normal,abnormal). Status defaults to
+// NEW when DISP is coded without one; Normal/Abnormal stay empty when omitted.
+type Disposition struct {
+	Status   string `json:"status"`             // NEW|OLD|SHR|MOD
+	Normal   string `json:"normal,omitempty"`   // DELETE|KEEP|PASS|CATLG|UNCATLG
+	Abnormal string `json:"abnormal,omitempty"` // DELETE|KEEP|CATLG|UNCATLG
+}
+
+// SpaceAlloc is the parsed SPACE= parameter.
+type SpaceAlloc struct {
+	Unit      string `json:"unit"`                // CYL|TRK|BLK (BLK: BlockLen-sized blocks or records)
+	BlockLen  int    `json:"block_len,omitempty"` // average block length, or record length with AVGREC
+	AvgRec    string `json:"avgrec,omitempty"`    // U|K|M: quantities are records (x1, x1024, x1048576)
+	Primary   int    `json:"primary"`             // primary quantity
+	Secondary int    `json:"secondary,omitempty"` // secondary quantity
+	Directory int    `json:"directory,omitempty"` // directory blocks (PDS) or index
+	Release   bool   `json:"release,omitempty"`   // RLSE
+	Contig    bool   `json:"contig,omitempty"`    // CONTIG
+	Round     bool   `json:"round,omitempty"`     // ROUND
+	Extent    string `json:"extent,omitempty"`    // MXIG|ALX
+}
+
+// DCBAttrs are the record attributes from DCB= subparameters and the
+// equivalent DD keywords (RECFM=, LRECL=, ...). Model names a model dataset
+// or referback coded as the first DCB subparameter.
+type DCBAttrs struct {
+	RECFM   string `json:"recfm,omitempty"`
+	LRECL   int    `json:"lrecl,omitempty"`
+	BLKSIZE int    `json:"blksize,omitempty"`
+	DSORG   string `json:"dsorg,omitempty"`
+	Model   string `json:"model,omitempty"`
+}
+
+// Volume is the parsed VOL= parameter.
+type Volume struct {
+	Serials []string `json:"serials,omitempty"` // SER=
+	Ref     string   `json:"ref,omitempty"`     // REF= dataset name or *.step.dd referback
+	Private bool     `json:"private,omitempty"`
+	Retain  bool     `json:"retain,omitempty"`
+}
+
+// Bytes converts a quantity in the allocation's unit to bytes.
+func (s SpaceAlloc) Bytes(qty int, g Geometry) float64 {
+	trkPerCyl := g.TracksPerCyl
+	if trkPerCyl <= 0 {
+		trkPerCyl = 15
+	}
+	bytesPerTrack := g.BytesPerTrack
+	if bytesPerTrack <= 0 {
+		bytesPerTrack = 56664
+	}
+	switch s.Unit {
+	case "CYL":
+		return float64(qty) * float64(trkPerCyl*bytesPerTrack)
+	case "TRK":
+		return float64(qty) * float64(bytesPerTrack)
+	}
+	mult := 1.0
+	switch s.AvgRec {
+	case "K":
+		mult = 1024
+	case "M":
+		mult = 1024 * 1024
+	}
+	return float64(qty) * float64(s.BlockLen) * mult
+}
+
+// PrimaryMB is the primary allocation in megabytes.
+func (s SpaceAlloc) PrimaryMB(g Geometry) float64 {
+	return s.Bytes(s.Primary, g) / (1024.0 * 1024.0)
+}
+
+// Members returns the DD followed by its concatenated members.
+func (d DD) Members() []DD {
+	if len(d.Concat) == 0 {
+		return []DD{d}
+	}
+	out := make([]DD, 0, 1+len(d.Concat))
+	out = append(out, d)
+	return append(out, d.Concat...)
+}
+
+// Status returns the DISP status, or "" when DISP is not coded.
+func (d DD) Status() string {
+	if d.Disposition == nil {
+		return ""
+	}
+	return d.Disposition.Status
+}
+
+// Creates reports whether the DD allocates or extends the dataset
+// (DISP=NEW or MOD).
+func (d DD) Creates() bool {
+	s := d.Status()
+	return s == "NEW" || s == "MOD"
+}
+
+// Keeps reports whether the normal disposition retains the dataset
+// (KEEP or CATLG).
+func (d DD) Keeps() bool {
+	return d.Disposition != nil && (d.Disposition.Normal == "KEEP" || d.Disposition.Normal == "CATLG")
+}
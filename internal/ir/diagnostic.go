@@ -0,0 +1,30 @@
+package ir
+
+import "fmt"
+
+// Diagnostic severities.
+const (
+	SeverityError   = "ERROR"
+	SeverityWarning = "WARNING"
+)
+
+// Diagnostic is a problem found while reading or expanding JCL. Code is a
+// stable identifier (e.g. PROC-NOT-FOUND) suitable for filtering.
+type Diagnostic struct {
+	Severity string `json:"severity"` // ERROR|WARNING
+	Code     string `json:"code"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Message  string `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	loc := d.File
+	if d.Line > 0 {
+		loc = fmt.Sprintf("%s:%d", d.File, d.Line)
+	}
+	if loc == "" {
+		return fmt.Sprintf("%s %s: %s", d.Severity, d.Code, d.Message)
+	}
+	return fmt.Sprintf("%s: %s %s: %s", loc, d.Severity, d.Code, d.Message)
+}
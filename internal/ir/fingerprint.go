@@ -0,0 +1,40 @@
+package ir
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// FingerprintOf returns the cross-run identity of a finding: a hash of its
+// rule, jobs, step, DD and dataset. Messages, evidence and source
+// locations are left out, so a finding keeps its fingerprint when its
+// wording changes or cards move.
+func FingerprintOf(f Finding) string {
+	jobs := append([]string{f.Job}, f.Jobs...)
+	for i := range jobs {
+		jobs[i] = strings.ToUpper(strings.TrimSpace(jobs[i]))
+	}
+	sort.Strings(jobs)
+	key := strings.Join([]string{
+		strings.ToUpper(strings.TrimSpace(f.RuleID)),
+		strings.Join(dedupe(jobs), ","),
+		strings.ToUpper(strings.TrimSpace(f.Step)),
+		strings.ToUpper(strings.TrimSpace(f.DD)),
+		strings.ToUpper(strings.TrimSpace(f.Dataset)),
+	}, "\x1f")
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// dedupe drops adjacent repeats from a sorted slice.
+func dedupe(s []string) []string {
+	out := s[:0]
+	for i, v := range s {
+		if i == 0 || v != s[i-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,28 @@
+package ir
+
+// Edit operations.
+const (
+	EditDelete = "delete" // remove the statement's cards and the in-stream data after them
+	EditSet    = "set"    // set a keyword parameter, adding it when not coded
+)
+
+// Fix is a suggested remediation for a finding: edits to the JCL the
+// finding points at. `jclift fix` renders them as a diff or applies them.
+type Fix struct {
+	Description string `json:"description"`
+	Edits       []Edit `json:"edits"`
+}
+
+// Edit is one change to a JCL statement. Loc.Line is the statement's first
+// card; for EditDelete, Loc.EndLine is the last card removed (a step's Loc
+// removes the EXEC statement and its DDs). Stmt and Name are checked
+// against the card before editing, so edits against a member changed since
+// the run are refused.
+type Edit struct {
+	Op    string    `json:"op"` // delete|set
+	Loc   *Location `json:"loc"`
+	Stmt  string    `json:"stmt"`           // operation field: EXEC or DD
+	Name  string    `json:"name,omitempty"` // name field as coded; empty for concatenation members
+	Param string    `json:"param,omitempty"`
+	Value string    `json:"value,omitempty"`
+}
@@ -0,0 +1,57 @@
+package ir
+
+import "time"
+
+// Finding lifecycle states, relative to the latest run of the source.
+const (
+	FindingNew       = "new"       // first seen in its latest run
+	FindingOpen      = "open"      // seen in earlier runs too
+	FindingRegressed = "regressed" // reappeared after being resolved
+	FindingResolved  = "resolved"  // absent from a later run of the same source
+)
+
+// FindingHistory is the lifecycle of one finding fingerprint across the
+// stored runs. Waived and otherwise suppressed findings count as seen: the
+// issue is still in the JCL.
+type FindingHistory struct {
+	Fingerprint string `json:"fingerprint"`
+	RuleID      string `json:"rule_id"`
+	Job         string `json:"job,omitempty"`
+	Step        string `json:"step,omitempty"`
+	DD          string `json:"dd,omitempty"`
+	Source      string `json:"source,omitempty"`
+
+	FirstSeenRun string    `json:"first_seen_run"`
+	FirstSeenAt  time.Time `json:"first_seen_at"`
+	LastSeenRun  string    `json:"last_seen_run"`
+	LastSeenAt   time.Time `json:"last_seen_at"`
+	Occurrences  int       `json:"occurrences"` // runs the finding was seen in
+
+	ResolvedRun string     `json:"resolved_run,omitempty"` // first later run without it
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+
+	Regressions  int        `json:"regressions"`             // times it came back after being resolved
+	RegressedRun string     `json:"regressed_run,omitempty"` // latest run it came back in
+	RegressedAt  *time.Time `json:"regressed_at,omitempty"`
+}
+
+// Status reports the lifecycle state of h.
+func (h FindingHistory) Status() string {
+	switch {
+	case h.ResolvedAt != nil:
+		return FindingResolved
+	case h.RegressedRun != "" && h.RegressedRun == h.LastSeenRun:
+		return FindingRegressed
+	case h.Occurrences <= 1:
+		return FindingNew
+	}
+	return FindingOpen
+}
+
+// Age is how long the finding had been open at t.
+func (h FindingHistory) Age(t time.Time) time.Duration {
+	if h.FirstSeenAt.IsZero() || t.Before(h.FirstSeenAt) {
+		return 0
+	}
+	return t.Sub(h.FirstSeenAt)
+}
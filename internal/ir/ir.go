@@ -13,6 +13,16 @@ type Run struct {
 	Context  Context   `json:"context"`
 	Jobs     []Job     `json:"jobs"`
 	Findings []Finding `json:"findings,omitempty"`
+
+	// Findings below the severity threshold, from disabled rules or waived
+	SuppressedFindings []SuppressedFinding `json:"suppressed_findings,omitempty"`
+
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"` // parser diagnostics
+	Lineage     *Lineage     `json:"lineage,omitempty"`     // dataset producer/consumer graph
+
+	// Lifecycle of the run's findings by fingerprint, filled in from
+	// storage once the run is saved
+	History map[string]FindingHistory `json:"history,omitempty"`
 }
 
 type Context struct {
@@ -20,19 +30,46 @@ type Context struct {
 	RuleSeverityThreshold string   `json:"rule_severity_threshold,omitempty"`
 	DisabledRules         []string `json:"disabled_rules,omitempty"`
 
+	// Effective per-rule settings: severity overrides, and the parameter
+	// values every parameterized rule ran with.
+	RuleSeverities map[string]string         `json:"rule_severities,omitempty"`
+	RuleParams     map[string]map[string]any `json:"rule_params,omitempty"`
+
 	Geometry Geometry  `json:"geometry,omitempty"`
 	Model    CostModel `json:"model,omitempty"`
-	
+
 	// NEW: how many findings were waived (by active waivers) during analyze
 	WaivedCount int `json:"waived_count,omitempty"`
+
+	// Per-rule evaluation counters and timings, by rule ID.
+	RuleStats []RuleStat `json:"rule_stats,omitempty"`
 }
 
 type Job struct {
 	Name          string `json:"name"`
 	Class         string `json:"class,omitempty"`
-	Owner         string `json:"owner,omitempty"`
+	Owner         string `json:"owner,omitempty"` // USER=, else a literal NOTIFY=
 	ProcsResolved bool   `json:"procs_resolved,omitempty"`
 	Steps         []Step `json:"steps"`
+
+	Loc *Location `json:"loc,omitempty"` // JOB statement through the job's last card
+
+	// JOB statement parameters as coded (symbols substituted).
+	Accounting string `json:"accounting,omitempty"`
+	Programmer string `json:"programmer,omitempty"`
+	MsgClass   string `json:"msgclass,omitempty"`
+	Notify     string `json:"notify,omitempty"`
+	Region     string `json:"region,omitempty"`
+	Time       string `json:"time,omitempty"`
+	TypRun     string `json:"typrun,omitempty"`
+	Restart    string `json:"restart,omitempty"`
+	User       string `json:"user,omitempty"`
+
+	JobLib []string `json:"joblib,omitempty"` // JOBLIB DD datasets, in search order
+
+	// JES2 control statements following the JOB statement.
+	JobParm map[string]string `json:"jobparm,omitempty"` // /*JOBPARM keywords
+	Route   []string          `json:"route,omitempty"`   // /*ROUTE operands, e.g. "PRINT RMT5"
 }
 
 type Step struct {
@@ -40,18 +77,62 @@ type Step struct {
 	Program     string          `json:"program"`
 	Ordinal     int             `json:"ordinal"`
 	DD          []DD            `json:"dd,omitempty"`
-	Conditions  string          `json:"conditions,omitempty"`
+	Conditions  string          `json:"conditions,omitempty"` // COND= as coded
+	Cond        *StepCond       `json:"cond,omitempty"`
+	When        []IfClause      `json:"when,omitempty"`      // enclosing IF constructs, outermost first
+	Proc        string          `json:"proc,omitempty"`      // PROC that supplied the step
+	ProcStep    string          `json:"proc_step,omitempty"` // step name inside that PROC
 	Annotations StepAnnotations `json:"annotations"`
+
+	// EXEC statement parameters (symbols substituted).
+	Parm        string   `json:"parm,omitempty"`         // PARM= with quotes/parentheses removed
+	ParmArgs    []string `json:"parm_args,omitempty"`    // PARM tokenized for known utilities (DFSORT, IDCAMS, IKJEFT01, ...)
+	ParmDD      string   `json:"parmdd,omitempty"`       // PARMDD= ddname
+	Region      string   `json:"region,omitempty"`       // as coded, e.g. 0M, 4096K
+	RegionKB    int      `json:"region_kb,omitempty"`    // REGION in KB; -1 for 0K/0M (no limit)
+	Time        string   `json:"time,omitempty"`         // as coded, e.g. (1,30), NOLIMIT
+	TimeSeconds int      `json:"time_seconds,omitempty"` // TIME in seconds; -1 for NOLIMIT/MAXIMUM/1440
+	AddrSpc     string   `json:"addrspc,omitempty"`      // VIRT|REAL
+	DynamNbr    int      `json:"dynamnbr,omitempty"`
+	Acct        string   `json:"acct,omitempty"`
+	StepLib     []string `json:"steplib,omitempty"` // STEPLIB DD datasets, in search order
+
+	Loc *Location `json:"loc,omitempty"` // EXEC statement through the step's last DD in the same file
 }
 
 type DD struct {
-	DDName  string `json:"ddname"`
-	Dataset string `json:"dataset,omitempty"`
-	DISP    string `json:"disp,omitempty"`
-	Space   string `json:"space,omitempty"`
-	DCB     string `json:"dcb,omitempty"`
-	Content string `json:"content,omitempty"` // SYSIN text
-	Temp    bool   `json:"temp,omitempty"`
+	DDName      string `json:"ddname"`
+	Dataset     string `json:"dataset,omitempty"`
+	DatasetRaw  string `json:"dataset_raw,omitempty"` // as coded, when symbols were substituted
+	Referback   string `json:"referback,omitempty"`   // DSN=*.step.dd as coded; Dataset holds the resolved name
+	DISP        string `json:"disp,omitempty"`
+	Space       string `json:"space,omitempty"`
+	DCB         string `json:"dcb,omitempty"`
+	Content     string `json:"content,omitempty"`      // in-stream records (DD * / DD DATA) or mirrored control cards; "DUMMY" for SYSIN DD DUMMY
+	Instream    string `json:"instream,omitempty"`     // "*" or "DATA" when Content holds in-stream records
+	Delimiter   string `json:"delimiter,omitempty"`    // what ended them: "/*", the DLM= value, "//" (next statement) or "EOF"
+	ContentFile string `json:"content_file,omitempty"` // library mirror file Content was read from; empty for in-stream data
+	Temp        bool   `json:"temp,omitempty"`
+
+	Loc *Location `json:"loc,omitempty"`
+
+	// Typed forms of the parameters above and of the other allocation
+	// keywords; nil/empty when not coded.
+	Disposition *Disposition `json:"disposition,omitempty"`
+	Alloc       *SpaceAlloc  `json:"alloc,omitempty"`
+	Attrs       *DCBAttrs    `json:"attrs,omitempty"` // DCB= and RECFM=/LRECL=/BLKSIZE=/DSORG=
+	Unit        string       `json:"unit,omitempty"`
+	Vol         *Volume      `json:"vol,omitempty"`
+	Sysout      string       `json:"sysout,omitempty"` // output class; "*" means MSGCLASS
+	DataClass   string       `json:"dataclas,omitempty"`
+	StorClass   string       `json:"storclas,omitempty"`
+	MgmtClass   string       `json:"mgmtclas,omitempty"`
+	Like        string       `json:"like,omitempty"`
+	RefDD       string       `json:"refdd,omitempty"`
+
+	// Further members of a concatenation (the unnamed DDs that follow this
+	// one), in order. Members carry the concatenation's DDName.
+	Concat []DD `json:"concat,omitempty"`
 }
 
 type StepAnnotations struct {
@@ -67,8 +148,13 @@ type Cost struct {
 
 type Finding struct {
 	ID          string         `json:"id"`
+	Fingerprint string         `json:"fingerprint,omitempty"` // identity across runs, see FingerprintOf
 	Job         string         `json:"job"`
+	Jobs        []string       `json:"jobs,omitempty"` // every job a cross-job finding involves, Job first
 	Step        string         `json:"step,omitempty"`
+	DD          string         `json:"dd,omitempty"`
+	Dataset     string         `json:"dataset,omitempty"` // dataset the finding is about; filled from the DD when the rule leaves it empty
+	Loc         *Location      `json:"loc,omitempty"`     // filled from the DD, step or job when the rule leaves it nil
 	RuleID      string         `json:"rule_id"`
 	Type        string         `json:"type"`     // COST|RISK
 	Severity    string         `json:"severity"` // LOW|MEDIUM|HIGH
@@ -77,8 +163,23 @@ type Finding struct {
 	SavingsMIPS float64        `json:"savings_mips,omitempty"`
 	SavingsUSD  float64        `json:"savings_usd,omitempty"`
 	Metadata    map[string]any `json:"metadata,omitempty"`
+	Fix         *Fix           `json:"fix,omitempty"` // suggested edits, for mechanical rules
+}
+
+// SuppressedFinding is a finding kept out of Run.Findings, with the reason.
+type SuppressedFinding struct {
+	Finding
+	Reason   string `json:"reason"`              // threshold|disabled|waived
+	WaiverID int64  `json:"waiver_id,omitempty"` // waiver that matched, for Reason waived
 }
 
+// Suppression reasons.
+const (
+	SuppressedThreshold = "threshold" // below the run's severity threshold
+	SuppressedDisabled  = "disabled"  // rule disabled by configuration
+	SuppressedWaived    = "waived"    // matched an active waiver
+)
+
 type Geometry struct {
 	TracksPerCyl  int `json:"tracks_per_cyl,omitempty"`
 	BytesPerTrack int `json:"bytes_per_track,omitempty"`
@@ -0,0 +1,49 @@
+package ir
+
+// Lineage is the producer/consumer graph of the datasets a run touches.
+// Each edge links one step to one dataset. GDG generations share the node
+// of their base with the relative generation on the edge; temporary (&&)
+// datasets get one node per job, named JOB.&&NAME.
+type Lineage struct {
+	Datasets []LineageDataset `json:"datasets"`
+	Edges    []LineageEdge    `json:"edges"`
+}
+
+// Lineage dataset kinds.
+const (
+	DatasetCataloged = "dataset"
+	DatasetGDG       = "gdg"
+	DatasetTemp      = "temp"
+)
+
+// LineageDataset is a dataset node with its producer and consumer counts.
+type LineageDataset struct {
+	Name      string `json:"name"`
+	Kind      string `json:"kind"` // dataset|gdg|temp
+	Producers int    `json:"producers"`
+	Consumers int    `json:"consumers"`
+}
+
+// Lineage edge access modes.
+const (
+	AccessCreate = "create" // DISP=NEW, or DISP omitted
+	AccessWrite  = "write"  // DISP=MOD, or DISP=OLD on an output DD
+	AccessRead   = "read"
+)
+
+// LineageEdge is one step's use of a dataset through one DD.
+type LineageEdge struct {
+	Dataset    string `json:"dataset"`
+	Generation string `json:"generation,omitempty"` // GDG relative generation as coded: +1, 0, -1
+	Job        string `json:"job"`
+	Step       string `json:"step"`
+	DD         string `json:"dd"`
+	Access     string `json:"access"`         // create|write|read
+	Disp       string `json:"disp,omitempty"` // DISP as coded
+	// Disposition is the parsed DISP; Status is NEW when DISP is omitted.
+	Disposition Disposition `json:"disposition"`
+	Loc         *Location   `json:"loc,omitempty"`
+}
+
+// Produces reports whether the edge writes the dataset.
+func (e LineageEdge) Produces() bool { return e.Access != AccessRead }
@@ -0,0 +1,24 @@
+package ir
+
+import "fmt"
+
+// Location is a range of source cards. Lines are 1-based; columns are
+// 1-based card columns within the statement field (1-71).
+type Location struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	EndLine int    `json:"end_line,omitempty"`
+	Col     int    `json:"col,omitempty"`
+	EndCol  int    `json:"end_col,omitempty"`
+}
+
+// String renders the location as file:line or file:line-endline.
+func (l *Location) String() string {
+	if l == nil {
+		return ""
+	}
+	if l.EndLine > l.Line {
+		return fmt.Sprintf("%s:%d-%d", l.File, l.Line, l.EndLine)
+	}
+	return fmt.Sprintf("%s:%d", l.File, l.Line)
+}
@@ -0,0 +1,25 @@
+package ir
+
+import "time"
+
+// RuleStat is how one rule fared in a run's evaluation: how often it ran,
+// how long it took and what it produced.
+type RuleStat struct {
+	Rule        string        `json:"rule"`
+	Scope       string        `json:"scope"`       // job|run
+	Invocations int           `json:"invocations"` // once per job, or once per run
+	Findings    int           `json:"findings"`    // emitted, reported or not
+	Suppressed  int           `json:"suppressed,omitempty"`
+	Panics      int           `json:"panics,omitempty"` // invocations that panicked; they yield no findings
+	LastPanic   string        `json:"last_panic,omitempty"`
+	Elapsed     time.Duration `json:"elapsed_ns"` // total time in the rule
+	Max         time.Duration `json:"max_ns"`     // slowest invocation
+}
+
+// Mean is the average time of one invocation.
+func (s RuleStat) Mean() time.Duration {
+	if s.Invocations == 0 {
+		return 0
+	}
+	return s.Elapsed / time.Duration(s.Invocations)
+}
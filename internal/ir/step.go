@@ -0,0 +1,10 @@
+package ir
+
+// LoadLibraries returns the libraries searched for the step's program:
+// STEPLIB when coded, else the job's JOBLIB.
+func (s Step) LoadLibraries(job *Job) []string {
+	if len(s.StepLib) > 0 || job == nil {
+		return s.StepLib
+	}
+	return job.JobLib
+}
@@ -0,0 +1,123 @@
+package lineage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+// Formats accepted by Write.
+const (
+	FormatText    = "text"
+	FormatJSON    = "json"
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+)
+
+// Write renders g in format (text, json, dot or mermaid).
+func Write(w io.Writer, g *ir.Lineage, format string) error {
+	switch strings.ToLower(format) {
+	case FormatText, "":
+		return WriteText(w, g)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(g)
+	case FormatDOT:
+		return WriteDOT(w, g)
+	case FormatMermaid:
+		return WriteMermaid(w, g)
+	}
+	return fmt.Errorf("unknown lineage format %q (text|json|dot|mermaid)", format)
+}
+
+// WriteText lists the edges of g as a table, one DD per line.
+func WriteText(w io.Writer, g *ir.Lineage) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DATASET\tGEN\tACCESS\tJOB\tSTEP\tDD\tDISP")
+	for _, e := range g.Edges {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.Dataset, e.Generation, e.Access, e.Job, e.Step, e.DD, e.Disp)
+	}
+	return tw.Flush()
+}
+
+// WriteDOT renders g for Graphviz: datasets are cylinders, steps are boxes,
+// edges point from producer to dataset and from dataset to consumer. Node
+// IDs are prefixed d: and s: so a dataset named like a JOB.STEP stays a
+// separate node; the labels carry the plain names.
+func WriteDOT(w io.Writer, g *ir.Lineage) error {
+	var b strings.Builder
+	b.WriteString("digraph lineage {\n  rankdir=LR;\n")
+	for _, d := range g.Datasets {
+		fmt.Fprintf(&b, "  %s [shape=cylinder, label=%s];\n", dotID("d:"+d.Name), dotID(d.Name))
+	}
+	for _, s := range steps(g) {
+		fmt.Fprintf(&b, "  %s [shape=box, label=%s];\n", dotID("s:"+s), dotID(s))
+	}
+	for _, e := range g.Edges {
+		from, to := dotID("s:"+stepKey(e)), dotID("d:"+e.Dataset)
+		if !e.Produces() {
+			from, to = to, from
+		}
+		fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", from, to, dotID(edgeLabel(e)))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid renders g as a Mermaid flowchart.
+func WriteMermaid(w io.Writer, g *ir.Lineage) error {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	ids := map[string]string{}
+	for i, d := range g.Datasets {
+		ids["d:"+d.Name] = fmt.Sprintf("d%d", i)
+		fmt.Fprintf(&b, "  d%d[(%s)]\n", i, mermaidText(d.Name))
+	}
+	for i, s := range steps(g) {
+		ids["s:"+s] = fmt.Sprintf("s%d", i)
+		fmt.Fprintf(&b, "  s%d[%s]\n", i, mermaidText(s))
+	}
+	for _, e := range g.Edges {
+		from, to := ids["s:"+stepKey(e)], ids["d:"+e.Dataset]
+		if !e.Produces() {
+			from, to = to, from
+		}
+		fmt.Fprintf(&b, "  %s -->|%s| %s\n", from, mermaidText(edgeLabel(e)), to)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// steps lists the JOB.STEP nodes of g in edge order.
+func steps(g *ir.Lineage) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, e := range g.Edges {
+		if k := stepKey(e); !seen[k] {
+			seen[k] = true
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+func edgeLabel(e ir.LineageEdge) string {
+	if e.Generation != "" {
+		return e.DD + " (" + e.Generation + ")"
+	}
+	return e.DD
+}
+
+func dotID(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func mermaidText(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
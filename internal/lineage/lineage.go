@@ -0,0 +1,190 @@
+// Package lineage builds the dataset producer/consumer graph of a run.
+package lineage
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+var gdgRe = regexp.MustCompile(`^(.+)\(([+-]?\d+)\)$`)
+
+// outputDDs are DD names that write their dataset even when DISP=OLD.
+var outputDDs = []string{"SORTOUT", "SORTOF", "SYSUT2", "SYSLMOD", "SYSPUNCH", "OUTFILE"}
+
+// Build returns the lineage of every DD in run that names a dataset, in
+// job, step and DD order.
+func Build(run *ir.Run) *ir.Lineage {
+	var edges []ir.LineageEdge
+	for _, job := range run.Jobs {
+		for _, st := range job.Steps {
+			for _, head := range st.DD {
+				for _, dd := range head.Members() {
+					name, gen, ok := Node(job.Name, dd)
+					if !ok {
+						continue
+					}
+					edges = append(edges, ir.LineageEdge{
+						Dataset:     name,
+						Generation:  gen,
+						Job:         job.Name,
+						Step:        st.Name,
+						DD:          dd.DDName,
+						Access:      Access(dd),
+						Disp:        dd.DISP,
+						Disposition: disposition(dd),
+						Loc:         dd.Loc,
+					})
+				}
+			}
+		}
+	}
+	return FromEdges(edges)
+}
+
+// Node returns the lineage node of dd's dataset in job and the GDG relative
+// generation, or false when the DD names no dataset (DUMMY, SYSOUT, an
+// unresolved referback).
+func Node(job string, dd ir.DD) (name, generation string, ok bool) {
+	ds := strings.ToUpper(strings.TrimSpace(dd.Dataset))
+	if ds == "" || ds == "NULLFILE" || strings.HasPrefix(ds, "*.") {
+		return "", "", false
+	}
+	if dd.Temp {
+		return job + "." + ds, "", true
+	}
+	if m := gdgRe.FindStringSubmatch(ds); m != nil {
+		return m[1], m[2], true
+	}
+	return ds, "", true
+}
+
+// disposition is the DD's parsed DISP, NEW when DISP is not coded.
+func disposition(dd ir.DD) ir.Disposition {
+	if dd.Disposition == nil {
+		return ir.Disposition{Status: "NEW"}
+	}
+	return *dd.Disposition
+}
+
+// kind classifies a node from the edges that touch it.
+func kind(e ir.LineageEdge) string {
+	switch {
+	case strings.Contains(e.Dataset, "&"): // JOB.&&NAME
+		return ir.DatasetTemp
+	case e.Generation != "":
+		return ir.DatasetGDG
+	}
+	return ir.DatasetCataloged
+}
+
+// Access classifies a DD's use of its dataset: NEW (or no DISP) creates,
+// MOD writes, OLD writes on output DDs and reads elsewhere, SHR reads.
+func Access(dd ir.DD) string {
+	switch dd.Status() {
+	case "", "NEW":
+		return ir.AccessCreate
+	case "MOD":
+		return ir.AccessWrite
+	case "OLD":
+		name := strings.ToUpper(dd.DDName)
+		for _, out := range outputDDs {
+			if strings.HasPrefix(name, out) {
+				return ir.AccessWrite
+			}
+		}
+	}
+	return ir.AccessRead
+}
+
+// FromEdges wraps edges in a Lineage with its dataset nodes, sorted by name.
+func FromEdges(edges []ir.LineageEdge) *ir.Lineage {
+	g := &ir.Lineage{Edges: edges}
+	index := map[string]int{}
+	for _, e := range edges {
+		i, ok := index[e.Dataset]
+		if !ok {
+			i = len(g.Datasets)
+			index[e.Dataset] = i
+			g.Datasets = append(g.Datasets, ir.LineageDataset{Name: e.Dataset, Kind: kind(e)})
+		}
+		if e.Generation != "" {
+			g.Datasets[i].Kind = ir.DatasetGDG
+		}
+		if e.Produces() {
+			g.Datasets[i].Producers++
+		} else {
+			g.Datasets[i].Consumers++
+		}
+	}
+	sort.Slice(g.Datasets, func(i, j int) bool { return g.Datasets[i].Name < g.Datasets[j].Name })
+	if g.Edges == nil {
+		g.Edges = []ir.LineageEdge{}
+	}
+	if g.Datasets == nil {
+		g.Datasets = []ir.LineageDataset{}
+	}
+	return g
+}
+
+// Trace returns the part of g around dataset: upstream, the steps producing
+// it and the datasets those steps read, recursively; downstream, the steps
+// reading it and the datasets they write, recursively. depth limits how many
+// datasets away the trace goes (no limit when depth <= 0). A GDG relative
+// generation on dataset is ignored.
+func Trace(g *ir.Lineage, dataset string, depth int) *ir.Lineage {
+	dataset = strings.ToUpper(strings.TrimSpace(dataset))
+	if m := gdgRe.FindStringSubmatch(dataset); m != nil {
+		dataset = m[1]
+	}
+
+	byDataset := map[string][]int{}
+	byStep := map[string][]int{}
+	for i, e := range g.Edges {
+		byDataset[e.Dataset] = append(byDataset[e.Dataset], i)
+		byStep[stepKey(e)] = append(byStep[stepKey(e)], i)
+	}
+
+	keep := map[int]bool{}
+	walk := func(upstream bool) {
+		frontier, seen := []string{dataset}, map[string]bool{dataset: true}
+		for level := 0; len(frontier) > 0 && (depth <= 0 || level < depth); level++ {
+			var next []string
+			for _, ds := range frontier {
+				for _, i := range byDataset[ds] {
+					// Upstream follows producers of ds, downstream its consumers.
+					if g.Edges[i].Produces() != upstream {
+						continue
+					}
+					keep[i] = true
+					for _, j := range byStep[stepKey(g.Edges[i])] {
+						// ... then the step's inputs (upstream) or outputs (downstream).
+						if g.Edges[j].Produces() == upstream {
+							continue
+						}
+						keep[j] = true
+						if d := g.Edges[j].Dataset; !seen[d] {
+							seen[d] = true
+							next = append(next, d)
+						}
+					}
+				}
+			}
+			frontier = next
+		}
+	}
+	walk(true)
+	walk(false)
+
+	var edges []ir.LineageEdge
+	for i, e := range g.Edges {
+		if keep[i] {
+			edges = append(edges, e)
+		}
+	}
+	return FromEdges(edges)
+}
+
+func stepKey(e ir.LineageEdge) string { return e.Job + "." + e.Step }
@@ -0,0 +1,245 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+// parseCond parses an EXEC COND= value: (code,op[,step[.procstep]]), a list
+// of such tests, EVEN/ONLY, or a list mixing both.
+func parseCond(v string) (*ir.StepCond, error) {
+	v = strings.ToUpper(strings.TrimSpace(v))
+	if v == "" {
+		return nil, nil
+	}
+	items := subparams(v)
+	// A single test "(4,LT)" strips to "4","LT"; a list strips to "(4,LT)",...
+	if len(items) >= 2 && !strings.HasPrefix(items[0], "(") && items[0] != "EVEN" && items[0] != "ONLY" {
+		items = []string{v}
+	}
+	c := &ir.StepCond{}
+	for _, it := range items {
+		switch it {
+		case "EVEN":
+			c.Even = true
+			continue
+		case "ONLY":
+			c.Only = true
+			continue
+		}
+		parts := subparams(it)
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("invalid COND test %q", it)
+		}
+		code, err := strconv.Atoi(parts[0])
+		if err != nil || code < 0 || code > 4095 {
+			return nil, fmt.Errorf("invalid COND code %q", parts[0])
+		}
+		op := parts[1]
+		if !isRelation(op) {
+			return nil, fmt.Errorf("invalid COND operator %q", op)
+		}
+		t := ir.CondTest{Code: code, Operator: op}
+		if len(parts) == 3 {
+			t.Step, t.ProcStep, _ = strings.Cut(parts[2], ".")
+		}
+		c.Tests = append(c.Tests, t)
+	}
+	if c.Even && c.Only {
+		return nil, fmt.Errorf("COND cannot specify both EVEN and ONLY")
+	}
+	return c, nil
+}
+
+func isRelation(op string) bool {
+	switch op {
+	case "GT", "GE", "EQ", "LT", "LE", "NE":
+		return true
+	}
+	return false
+}
+
+// parseIfExpr parses the relational expression of an IF statement. AND and
+// OR share one precedence level and group left to right; NOT binds tightest.
+func parseIfExpr(text string) (*ir.CondExpr, error) {
+	toks, err := ifTokens(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &ifParser{toks: toks}
+	e, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.toks) {
+		return nil, fmt.Errorf("unexpected %q in IF expression", p.toks[p.pos])
+	}
+	return e, nil
+}
+
+// ifTokens splits an IF expression into words, parentheses and operators.
+// The not sign may be coded as ¬, ^ or NOT.
+func ifTokens(s string) ([]string, error) {
+	s = strings.ReplaceAll(s, "¬", "^")
+	var out []string
+	for i := 0; i < len(s); {
+		ch := s[i]
+		switch {
+		case ch == ' ':
+			i++
+		case ch == '(' || ch == ')' || ch == '&' || ch == '|':
+			out = append(out, string(ch))
+			i++
+		case ch == '^' || ch == '<' || ch == '>' || ch == '=':
+			j := i + 1
+			for j < len(s) && j-i < 2 && strings.IndexByte("<>=", s[j]) != -1 {
+				j++
+			}
+			out = append(out, s[i:j])
+			i = j
+		case isSymbolChar(ch) || ch == '.':
+			j := i
+			for j < len(s) && (isSymbolChar(s[j]) || s[j] == '.') {
+				j++
+			}
+			out = append(out, strings.ToUpper(s[i:j]))
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected %q in IF expression", ch)
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("empty IF expression")
+	}
+	return out, nil
+}
+
+var relations = map[string]string{
+	">": "GT", ">=": "GE", "=": "EQ", "<": "LT", "<=": "LE", "^=": "NE",
+	"^<": "GE", "^>": "LE",
+	"GT": "GT", "GE": "GE", "EQ": "EQ", "LT": "LT", "LE": "LE", "NE": "NE",
+	"NL": "GE", "NG": "LE",
+}
+
+type ifParser struct {
+	toks []string
+	pos  int
+}
+
+func (p *ifParser) peek() string {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return ""
+}
+
+func (p *ifParser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *ifParser) expr() (*ir.CondExpr, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var op string
+		switch p.peek() {
+		case "&", "AND":
+			op = "AND"
+		case "|", "OR":
+			op = "OR"
+		default:
+			return left, nil
+		}
+		p.next()
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = &ir.CondExpr{Op: op, Left: left, Right: right}
+	}
+}
+
+func (p *ifParser) unary() (*ir.CondExpr, error) {
+	switch p.peek() {
+	case "^", "NOT":
+		p.next()
+		e, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &ir.CondExpr{Op: "NOT", Left: e}, nil
+	case "(":
+		p.next()
+		e, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing ) in IF expression")
+		}
+		return e, nil
+	}
+	return p.relation()
+}
+
+// relation parses [step[.procstep].]keyword [op value].
+func (p *ifParser) relation() (*ir.CondExpr, error) {
+	tok := p.next()
+	if tok == "" {
+		return nil, fmt.Errorf("IF expression ends early")
+	}
+	parts := strings.Split(tok, ".")
+	e := &ir.CondExpr{Op: "REL", Keyword: parts[len(parts)-1]}
+	switch len(parts) {
+	case 1:
+	case 2:
+		e.Step = parts[0]
+	case 3:
+		e.Step, e.ProcStep = parts[0], parts[1]
+	default:
+		return nil, fmt.Errorf("invalid IF operand %q", tok)
+	}
+	switch e.Keyword {
+	case "RC", "ABEND", "ABENDCC", "RUN":
+	default:
+		return nil, fmt.Errorf("invalid IF keyword %q", e.Keyword)
+	}
+	if e.Keyword == "RUN" && e.Step == "" {
+		return nil, fmt.Errorf("RUN requires a step name")
+	}
+
+	rel, ok := relations[p.peek()]
+	if !ok {
+		if e.Keyword == "RC" || e.Keyword == "ABENDCC" {
+			return nil, fmt.Errorf("%s requires a comparison", e.Keyword)
+		}
+		// ABEND and RUN alone test for TRUE.
+		e.Relation, e.Value = "EQ", "TRUE"
+		return e, nil
+	}
+	p.next()
+	e.Relation = rel
+	e.Value = p.next()
+	switch e.Keyword {
+	case "RC":
+		if _, err := strconv.Atoi(e.Value); err != nil {
+			return nil, fmt.Errorf("invalid RC value %q", e.Value)
+		}
+	case "ABEND", "RUN":
+		if e.Value != "TRUE" && e.Value != "FALSE" {
+			return nil, fmt.Errorf("invalid %s value %q", e.Keyword, e.Value)
+		}
+	case "ABENDCC":
+		if e.Value == "" || (e.Value[0] != 'S' && e.Value[0] != 'U') {
+			return nil, fmt.Errorf("invalid ABENDCC value %q", e.Value)
+		}
+	}
+	return e, nil
+}
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+// loadControlCards fills Content for DDs whose dataset is mirrored by a
+// SYSIN library: DSN=PDS(MEMBER) reads the member from the library mirroring
+// PDS, a sequential DSN reads the file configured for it. In-stream data
+// and DUMMY are left alone.
+func loadControlCards(x *expander, steps []ir.Step) {
+	if len(x.lib.sysin) == 0 {
+		return
+	}
+	for si := range steps {
+		for di := range steps[si].DD {
+			dd := &steps[si].DD[di]
+			loadDDCards(x, steps[si].Name, dd)
+			for ci := range dd.Concat {
+				loadDDCards(x, steps[si].Name, &dd.Concat[ci])
+			}
+		}
+	}
+}
+
+func loadDDCards(x *expander, step string, dd *ir.DD) {
+	if dd.Content != "" || dd.Instream != "" || dd.Dataset == "" {
+		return
+	}
+	text, file, mapped := x.lib.controlCards(dd.Dataset)
+	switch {
+	case file != "":
+		dd.Content, dd.ContentFile = text, file
+	case mapped:
+		var at statement
+		if dd.Loc != nil {
+			at = statement{File: dd.Loc.File, Line: dd.Loc.Line}
+		}
+		x.warnf(at, CodeControlCardsMissing, "step %s DD %s: %s not found in the library mirror", step, dd.DDName, dd.Dataset)
+	}
+}
+
+// controlCards returns the records of dsn from the SYSIN libraries and the
+// file they were read from. mapped reports whether a library mirrors the
+// dataset, so a missing member can be told apart from an unmapped dataset.
+func (l *library) controlCards(dsn string) (text, file string, mapped bool) {
+	name, member := strings.ToUpper(dsn), ""
+	if i := strings.IndexByte(name, '('); i > 0 && strings.HasSuffix(name, ")") {
+		name, member = name[:i], name[i+1:len(name)-1]
+		if member == "" || member[0] == '+' || member[0] == '-' || member[0] >= '0' && member[0] <= '9' {
+			return "", "", false // GDG relative generation, not a member
+		}
+	}
+	for _, lib := range l.sysin {
+		if !strings.EqualFold(lib.Dataset, name) {
+			continue
+		}
+		mapped = true
+		p := ""
+		if member != "" {
+			p = l.members(lib.Dir)[member]
+		} else if st, err := os.Stat(lib.Dir); err == nil && !st.IsDir() {
+			p = lib.Dir
+		}
+		if p == "" {
+			continue
+		}
+		if text, ok := l.readCards(p); ok {
+			return text, p, true
+		}
+	}
+	return "", "", mapped
+}
+
+// readCards reads and decodes a control-card member, once per Parse.
+func (l *library) readCards(p string) (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if text, ok := l.cards[p]; ok {
+		if text == nil {
+			return "", false
+		}
+		return *text, true
+	}
+	l.cards[p] = nil // failed reads are reported once
+	data, err := os.ReadFile(p)
+	if err != nil {
+		l.diags.add(ir.SeverityError, CodeReadError, p, 0, "%v", err)
+		return "", false
+	}
+	srcs, err := decodeInput(p, data, l.encoding)
+	if err == nil && len(srcs) == 0 {
+		err = fmt.Errorf("%s: no members", p)
+	}
+	if err != nil {
+		l.diags.add(ir.SeverityError, CodeReadError, p, 0, "%v", err)
+		return "", false
+	}
+	text := strings.ReplaceAll(string(bytes.TrimRight(srcs[0].Text, "\r\n")), "\r\n", "\n")
+	if text != "" {
+		text += "\n"
+	}
+	l.cards[p] = &text
+	return text, true
+}
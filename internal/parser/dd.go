@@ -0,0 +1,186 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+// parseAllocation fills the typed allocation fields of dd from its operands.
+func parseAllocation(dd *ir.DD, ps params) {
+	get := func(key string) string {
+		v, _ := ps.get(key)
+		return strings.ToUpper(strings.TrimSpace(v))
+	}
+	if v := get("DISP"); v != "" {
+		dd.Disposition = parseDisp(v)
+	}
+	if v := get("SPACE"); v != "" {
+		dd.Alloc = parseSpace(v, get("AVGREC"))
+	}
+	dd.Attrs = parseDCB(get("DCB"), ps)
+	if v := get("VOL"); v != "" {
+		dd.Vol = parseVol(v)
+	} else if v := get("VOLUME"); v != "" {
+		dd.Vol = parseVol(v)
+	}
+	if u := subparams(get("UNIT")); len(u) > 0 {
+		dd.Unit = u[0]
+	}
+	if v, ok := ps.get("SYSOUT"); ok {
+		if sp := subparams(strings.ToUpper(v)); len(sp) > 0 {
+			dd.Sysout = sp[0]
+		}
+		if dd.Sysout == "" {
+			dd.Sysout = "*"
+		}
+	}
+	dd.DataClass = get("DATACLAS")
+	dd.StorClass = get("STORCLAS")
+	dd.MgmtClass = get("MGMTCLAS")
+	dd.Like = get("LIKE")
+	dd.RefDD = get("REFDD")
+}
+
+// parseDisp parses DISP=status or DISP=(status,normal,abnormal).
+func parseDisp(v string) *ir.Disposition {
+	sp := subparams(v)
+	d := &ir.Disposition{}
+	if len(sp) > 0 {
+		d.Status = strings.TrimSpace(sp[0])
+	}
+	if d.Status == "" {
+		d.Status = "NEW"
+	}
+	if len(sp) > 1 {
+		d.Normal = strings.TrimSpace(sp[1])
+	}
+	if len(sp) > 2 {
+		d.Abnormal = strings.TrimSpace(sp[2])
+	}
+	return d
+}
+
+// parseSpace parses SPACE=(unit,(primary[,secondary[,directory]])[,RLSE]
+// [,CONTIG|MXIG|ALX][,ROUND]). unit is CYL, TRK or an average block length;
+// avgrec (U/K/M) turns the block length into a record length. Returns nil
+// when the primary quantity is missing or not numeric.
+func parseSpace(v, avgrec string) *ir.SpaceAlloc {
+	sp := subparams(v)
+	if len(sp) < 2 {
+		return nil
+	}
+	s := &ir.SpaceAlloc{AvgRec: avgrec}
+	switch unit := strings.TrimSpace(sp[0]); unit {
+	case "CYL", "TRK":
+		s.Unit = unit
+	default:
+		n, err := strconv.Atoi(unit)
+		if err != nil {
+			return nil
+		}
+		s.Unit, s.BlockLen = "BLK", n
+	}
+
+	qty := subparams(sp[1])
+	if len(qty) == 0 {
+		return nil
+	}
+	var err error
+	if s.Primary, err = strconv.Atoi(strings.TrimSpace(qty[0])); err != nil {
+		return nil
+	}
+	if len(qty) > 1 {
+		s.Secondary, _ = strconv.Atoi(strings.TrimSpace(qty[1]))
+	}
+	if len(qty) > 2 {
+		s.Directory, _ = strconv.Atoi(strings.TrimSpace(qty[2]))
+	}
+
+	for _, opt := range sp[2:] {
+		switch strings.TrimSpace(opt) {
+		case "RLSE":
+			s.Release = true
+		case "CONTIG":
+			s.Contig = true
+		case "MXIG", "ALX":
+			s.Extent = strings.TrimSpace(opt)
+		case "ROUND":
+			s.Round = true
+		}
+	}
+	return s
+}
+
+// parseDCB merges DCB= subparameters with the RECFM=/LRECL=/BLKSIZE=/DSORG=
+// DD keywords, which take precedence. A leading positional DCB subparameter
+// (DCB=model.dsn or DCB=*.STEP.DD) is recorded as the model.
+func parseDCB(dcb string, ps params) *ir.DCBAttrs {
+	a := &ir.DCBAttrs{}
+	apply := func(key, val string) {
+		val = strings.ToUpper(strings.TrimSpace(val))
+		switch key {
+		case "RECFM":
+			a.RECFM = val
+		case "LRECL":
+			a.LRECL, _ = strconv.Atoi(val)
+		case "BLKSIZE":
+			a.BLKSIZE, _ = strconv.Atoi(val)
+		case "DSORG":
+			a.DSORG = val
+		}
+	}
+	if dcb != "" {
+		for i, item := range subparams(dcb) {
+			if k := keywordEnd(item); k > 0 {
+				apply(strings.ToUpper(item[:k]), item[k+1:])
+			} else if i == 0 {
+				a.Model = strings.TrimSpace(item)
+			}
+		}
+	}
+	for _, p := range ps {
+		apply(p.Key, p.Value)
+	}
+	if *a == (ir.DCBAttrs{}) {
+		return nil
+	}
+	return a
+}
+
+// parseVol parses VOL=SER=x, VOL=(,,,,SER=(a,b)), VOL=REF=dsn and the
+// PRIVATE/RETAIN positionals.
+func parseVol(v string) *ir.Volume {
+	vol := &ir.Volume{}
+	var items []string
+	if keywordEnd(v) > 0 {
+		items = []string{v}
+	} else {
+		items = subparams(v)
+	}
+	for i, item := range items {
+		item = strings.TrimSpace(item)
+		if k := keywordEnd(item); k > 0 {
+			val := item[k+1:]
+			switch strings.ToUpper(item[:k]) {
+			case "SER":
+				for _, s := range subparams(val) {
+					if s = strings.TrimSpace(s); s != "" {
+						vol.Serials = append(vol.Serials, s)
+					}
+				}
+			case "REF":
+				vol.Ref = val
+			}
+			continue
+		}
+		switch {
+		case i == 0 && item == "PRIVATE":
+			vol.Private = true
+		case i == 1 && item == "RETAIN":
+			vol.Retain = true
+		}
+	}
+	return vol
+}
@@ -0,0 +1,453 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Input encodings accepted by Options.Encoding. Auto detects XMIT archives,
+// IEBCOPY unloads and EBCDIC text, reading anything else as ASCII/UTF-8;
+// EBCDIC content found in auto mode is decoded as code page 1047.
+const (
+	EncodingAuto    = "auto"
+	EncodingASCII   = "ascii"
+	EncodingIBM1047 = "ibm1047"
+	EncodingIBM037  = "ibm037"
+)
+
+// recordLen is the record length assumed for fixed-record exports (FB-80).
+const recordLen = 80
+
+// ValidEncoding reports whether name is an accepted Options.Encoding value
+// (empty means auto).
+func ValidEncoding(name string) bool {
+	switch normalizeEncoding(name) {
+	case EncodingAuto, EncodingASCII, EncodingIBM1047, EncodingIBM037:
+		return true
+	}
+	return false
+}
+
+func normalizeEncoding(name string) string {
+	switch n := strings.ToLower(strings.TrimSpace(name)); n {
+	case "":
+		return EncodingAuto
+	case "utf-8", "utf8":
+		return EncodingASCII
+	case "cp1047", "ebcdic", "1047":
+		return EncodingIBM1047
+	case "cp037", "037":
+		return EncodingIBM037
+	default:
+		return n
+	}
+}
+
+// source is one member of an input file, decoded to newline-delimited text.
+// Archives yield one source per PDS member, named archive(MEMBER).
+type source struct {
+	Name   string
+	Member string // member name used for jobs without a JOB card
+	Text   []byte
+}
+
+// decodeInput turns the raw bytes of an input file into one or more
+// sources according to encoding.
+func decodeInput(path string, data []byte, encoding string) ([]source, error) {
+	enc := normalizeEncoding(encoding)
+	if !ValidEncoding(enc) {
+		return nil, fmt.Errorf("unknown input encoding %q", encoding)
+	}
+	base := memberName(path)
+	if enc == EncodingASCII {
+		return []source{{Name: path, Member: base, Text: data}}, nil
+	}
+	cp := &cp1047
+	if enc == EncodingIBM037 {
+		cp = &cp037
+	}
+
+	switch {
+	case isXmit(data):
+		return decodeXmit(path, data, cp)
+	case isUnload(data):
+		recs, err := variableRecords(data)
+		if err != nil {
+			return nil, err
+		}
+		return decodeUnload(path, recs, cp)
+	case enc == EncodingAuto && !looksEBCDIC(data):
+		if bytes.IndexByte(data, '\n') < 0 && len(data) > recordLen && len(data)%recordLen == 0 {
+			return []source{{Name: path, Member: base, Text: joinRecords(fixedRecords(data, recordLen), nil)}}, nil
+		}
+		return []source{{Name: path, Member: base, Text: data}}, nil
+	}
+
+	// EBCDIC text: records separated by NL/LF when transferred as text,
+	// otherwise fixed 80-byte records. Empty records are kept so line
+	// numbers match the source; only a final terminator is dropped.
+	var recs [][]byte
+	if sep := textSeparator(data); sep != 0 {
+		recs = bytes.Split(bytes.TrimSuffix(data, []byte{sep}), []byte{sep})
+	} else {
+		recs = fixedRecords(data, recordLen)
+	}
+	return []source{{Name: path, Member: base, Text: joinRecords(recs, cp)}}, nil
+}
+
+// textSeparator returns the EBCDIC record separator of data, NL (0x15)
+// or else LF (0x25), or 0 if it has neither.
+func textSeparator(data []byte) byte {
+	for _, sep := range []byte{0x15, 0x25} {
+		if bytes.IndexByte(data, sep) >= 0 {
+			return sep
+		}
+	}
+	return 0
+}
+
+// looksEBCDIC reports whether data starts like EBCDIC JCL: "//" or "/*"
+// (0x61 0x61 / 0x61 0x5C) or a record of EBCDIC blanks.
+func looksEBCDIC(data []byte) bool {
+	if len(data) < 2 {
+		return false
+	}
+	if data[0] == 0x61 && (data[1] == 0x61 || data[1] == 0x5C) {
+		return true
+	}
+	return data[0] == 0x40 && data[1] == 0x40
+}
+
+// fixedRecords cuts data into n-byte records; a short final record is kept.
+func fixedRecords(data []byte, n int) [][]byte {
+	var out [][]byte
+	for len(data) > 0 {
+		k := min(n, len(data))
+		out = append(out, data[:k])
+		data = data[k:]
+	}
+	return out
+}
+
+// joinRecords decodes each record with cp (nil for ASCII), drops trailing
+// blanks and joins the records with newlines.
+func joinRecords(recs [][]byte, cp *[256]rune) []byte {
+	var b bytes.Buffer
+	for _, r := range recs {
+		if cp == nil {
+			b.Write(bytes.TrimRight(r, " \x00"))
+		} else {
+			b.WriteString(strings.TrimRight(ebcdic(r, cp), " \x00"))
+		}
+		b.WriteByte('\n')
+	}
+	return b.Bytes()
+}
+
+// ebcdic decodes b with code page cp.
+func ebcdic(b []byte, cp *[256]rune) string {
+	var sb strings.Builder
+	sb.Grow(len(b))
+	for _, c := range b {
+		sb.WriteRune(cp[c])
+	}
+	return sb.String()
+}
+
+// memberName is the base name of path without its extension.
+func memberName(path string) string {
+	base := path[strings.LastIndexAny(path, `/\`)+1:]
+	if i := strings.LastIndexByte(base, '.'); i > 0 {
+		base = base[:i]
+	}
+	return base
+}
+
+// TSO TRANSMIT (NETDATA) archives are FB-80 files holding a stream of
+// segments: a length byte (including the two header bytes), a flag byte and
+// the segment data. Segments are concatenated into logical records; control
+// records start with INMRnn.
+const (
+	segFirst   = 0x80
+	segLast    = 0x40
+	segControl = 0x20
+
+	inmDsorg = 0x003C // INMDSORG text unit
+	inmUtiln = 0x1028 // INMUTILN text unit
+
+	dsorgPO = 0x0200
+)
+
+// ebcdicINMR is "INMR" in EBCDIC.
+var ebcdicINMR = []byte{0xC9, 0xD5, 0xD4, 0xD9}
+
+func isXmit(data []byte) bool {
+	return len(data) > 8 && data[1]&segControl != 0 && bytes.Equal(data[2:6], ebcdicINMR) &&
+		data[6] == 0xF0 && data[7] == 0xF1
+}
+
+// xmitFile is one transmitted file: the utilities named by its INMR02
+// records and its data records.
+type xmitFile struct {
+	utilities []string
+	dsorg     uint16
+	records   [][]byte
+}
+
+// decodeXmit extracts the dataset carried by a TRANSMIT archive. A PDS
+// (unloaded by IEBCOPY) yields one source per member; a sequential dataset
+// yields one source.
+func decodeXmit(path string, data []byte, cp *[256]rune) ([]source, error) {
+	var (
+		files   = map[uint32]*xmitFile{}
+		order   []uint32 // file numbers in INMR02 order
+		current *xmitFile
+		started int // INMR03 records seen
+		rec     []byte
+	)
+	file := func(n uint32) *xmitFile {
+		if f, ok := files[n]; ok {
+			return f
+		}
+		f := &xmitFile{}
+		files[n] = f
+		order = append(order, n)
+		return f
+	}
+	for pos := 0; pos < len(data); {
+		if pos+2 > len(data) {
+			return nil, fmt.Errorf("%s: truncated XMIT segment at offset %d", path, pos)
+		}
+		n, flags := int(data[pos]), data[pos+1]
+		if n < 2 || pos+n > len(data) {
+			return nil, fmt.Errorf("%s: malformed XMIT segment at offset %d", path, pos)
+		}
+		if flags&segFirst != 0 {
+			rec = nil
+		}
+		rec = append(rec, data[pos+2:pos+n]...)
+		pos += n
+		if flags&segLast == 0 {
+			continue
+		}
+		if flags&segControl == 0 {
+			if current == nil {
+				return nil, fmt.Errorf("%s: XMIT data record before INMR03", path)
+			}
+			current.records = append(current.records, rec)
+			continue
+		}
+		if len(rec) < 6 || !bytes.Equal(rec[:4], ebcdicINMR) {
+			continue
+		}
+		switch ebcdic(rec[4:6], cp) {
+		case "02":
+			if len(rec) < 10 {
+				return nil, fmt.Errorf("%s: short INMR02 record", path)
+			}
+			f := file(binary.BigEndian.Uint32(rec[6:10]))
+			units := textUnits(rec[10:])
+			if u, ok := units[inmUtiln]; ok {
+				f.utilities = append(f.utilities, strings.TrimSpace(ebcdic(u, cp)))
+			}
+			if u, ok := units[inmDsorg]; ok && len(u) == 2 {
+				f.dsorg = binary.BigEndian.Uint16(u)
+			}
+		case "03":
+			started++
+			if started > len(order) {
+				return nil, fmt.Errorf("%s: INMR03 without a matching INMR02", path)
+			}
+			current = files[order[started-1]]
+		case "06":
+			pos = len(data)
+		}
+	}
+
+	// The dataset is the last file; a leading file is the TRANSMIT message.
+	if len(order) == 0 {
+		return nil, fmt.Errorf("%s: XMIT archive carries no dataset", path)
+	}
+	f := files[order[len(order)-1]]
+	if f.dsorg&dsorgPO != 0 || slices.Contains(f.utilities, "IEBCOPY") {
+		return decodeUnload(path, f.records, cp)
+	}
+	return []source{{Name: path, Member: memberName(path), Text: joinRecords(f.records, cp)}}, nil
+}
+
+// textUnits parses NETDATA text units (key, count, then count length-prefixed
+// values) and returns the first value of each key.
+func textUnits(b []byte) map[uint16][]byte {
+	out := map[uint16][]byte{}
+	for len(b) >= 4 {
+		key, count := binary.BigEndian.Uint16(b), int(binary.BigEndian.Uint16(b[2:]))
+		b = b[4:]
+		for i := 0; i < count && len(b) >= 2; i++ {
+			n := int(binary.BigEndian.Uint16(b))
+			if 2+n > len(b) {
+				return out
+			}
+			if i == 0 {
+				out[key] = b[2 : 2+n]
+			}
+			b = b[2+n:]
+		}
+	}
+	return out
+}
+
+// IEBCOPY unload format: COPYR1 (dataset attributes, eye-catcher CA6D0F at
+// offset 1), COPYR2 (extent information), directory blocks of 276 bytes
+// (12-byte count, 8-byte key, 256-byte data), then member data blocks, each
+// preceded by a 12-byte count (F M BB CC HH R KL DLDL); a block with DL=0
+// ends a member.
+const (
+	dirBlockLen = 276
+	countLen    = 12
+
+	recfmF = 0x80
+	recfmV = 0x40
+)
+
+var copyr1Eye = []byte{0xCA, 0x6D, 0x0F}
+
+// isUnload reports whether data is an IEBCOPY unload transferred with RDWs
+// (the first record's RDW followed by COPYR1).
+func isUnload(data []byte) bool {
+	return len(data) > 8 && data[2] == 0 && data[3] == 0 && bytes.Equal(data[5:8], copyr1Eye)
+}
+
+// variableRecords splits RDW-prefixed records.
+func variableRecords(data []byte) ([][]byte, error) {
+	var out [][]byte
+	for pos := 0; pos < len(data); {
+		if pos+4 > len(data) {
+			return nil, fmt.Errorf("truncated RDW at offset %d", pos)
+		}
+		n := int(binary.BigEndian.Uint16(data[pos:]))
+		if n < 4 || pos+n > len(data) {
+			return nil, fmt.Errorf("bad RDW length %d at offset %d", n, pos)
+		}
+		out = append(out, data[pos+4:pos+n])
+		pos += n
+	}
+	return out, nil
+}
+
+type dirEntry struct {
+	name  string
+	ttr   uint32
+	alias bool
+}
+
+// decodeUnload rebuilds the members of an IEBCOPY-unloaded PDS, leaving
+// out directory aliases.
+func decodeUnload(path string, recs [][]byte, cp *[256]rune) ([]source, error) {
+	if len(recs) < 3 || len(recs[0]) < 12 || !bytes.Equal(recs[0][1:4], copyr1Eye) {
+		return nil, fmt.Errorf("%s: not an IEBCOPY unload (COPYR1 missing)", path)
+	}
+	if recs[0][0]&0x01 != 0 {
+		return nil, fmt.Errorf("%s: PDSE unloads are not supported", path)
+	}
+	lrecl := int(binary.BigEndian.Uint16(recs[0][8:10]))
+	recfm := recs[0][10]
+
+	// Directory, starting after COPYR1 and COPYR2.
+	var entries []dirEntry
+	i := 2
+	for done := false; i < len(recs) && !done; i++ {
+		for blk := recs[i]; len(blk) >= dirBlockLen && !done; blk = blk[dirBlockLen:] {
+			d := blk[countLen+8 : dirBlockLen]
+			used := min(int(binary.BigEndian.Uint16(d)), len(d))
+			for e := d[2:used]; len(e) >= 8; {
+				if bytes.Equal(e[:8], bytes.Repeat([]byte{0xFF}, 8)) {
+					done = true
+					break
+				}
+				if len(e) < 12 {
+					break
+				}
+				c := e[11]
+				entries = append(entries, dirEntry{
+					name:  strings.TrimSpace(ebcdic(e[:8], cp)),
+					ttr:   uint32(e[8])<<16 | uint32(e[9])<<8 | uint32(e[10]),
+					alias: c&0x80 != 0,
+				})
+				e = e[min(12+int(c&0x1F)*2, len(e)):]
+			}
+		}
+	}
+
+	// Member data comes in TTR order, one run of blocks per distinct TTR.
+	var data []byte
+	for _, r := range recs[i:] {
+		data = append(data, r...)
+	}
+	var members [][][]byte
+	var blocks [][]byte
+	for pos := 0; pos+countLen <= len(data); {
+		kl, dl := int(data[pos+9]), int(binary.BigEndian.Uint16(data[pos+10:]))
+		end := pos + countLen + kl + dl
+		if end > len(data) {
+			return nil, fmt.Errorf("%s: truncated member data block", path)
+		}
+		if dl == 0 {
+			members = append(members, blocks)
+			blocks = nil
+		} else {
+			blocks = append(blocks, data[pos+countLen+kl:end])
+		}
+		pos = end
+	}
+
+	var ttrs []uint32
+	seen := map[uint32]bool{}
+	for _, e := range entries {
+		if !seen[e.ttr] {
+			seen[e.ttr] = true
+			ttrs = append(ttrs, e.ttr)
+		}
+	}
+	sort.Slice(ttrs, func(a, b int) bool { return ttrs[a] < ttrs[b] })
+	content := map[uint32][]byte{}
+	for k, ttr := range ttrs {
+		if k >= len(members) {
+			return nil, fmt.Errorf("%s: directory lists more members than the unload holds", path)
+		}
+		content[ttr] = joinRecords(blockRecords(members[k], recfm, lrecl), cp)
+	}
+
+	// An alias shares its primary member's data: listing it too would
+	// parse the same jobs twice.
+	var out []source
+	for _, e := range entries {
+		if e.alias {
+			continue
+		}
+		out = append(out, source{Name: path + "(" + e.name + ")", Member: e.name, Text: content[e.ttr]})
+	}
+	return out, nil
+}
+
+// blockRecords splits member data blocks into logical records: fixed
+// records of lrecl bytes, or RDW-prefixed records after a block descriptor.
+func blockRecords(blocks [][]byte, recfm byte, lrecl int) [][]byte {
+	var out [][]byte
+	for _, b := range blocks {
+		switch {
+		case recfm&0xC0 == recfmV && len(b) >= 4:
+			if recs, err := variableRecords(b[4:]); err == nil {
+				out = append(out, recs...)
+			}
+		case recfm&0xC0 == recfmF && lrecl > 0:
+			out = append(out, fixedRecords(b, lrecl)...)
+		default:
+			out = append(out, b)
+		}
+	}
+	return out
+}
@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+// Diagnostic codes.
+const (
+	CodeReadError           = "READ-ERROR"            // member could not be read
+	CodeNoInput             = "NO-INPUT"              // nothing JCL-like under the path
+	CodeDuplicateInput      = "INPUT-DUPLICATE"       // file reached twice (symlink or overlapping sources)
+	CodeDuplicateMember     = "MEMBER-DUPLICATE"      // member name held by more than one file
+	CodeMalformedCard       = "CARD-MALFORMED"        // bad name/operation field or broken continuation
+	CodeUnknownKeyword      = "KEYWORD-UNKNOWN"       // keyword not valid on JOB/EXEC/DD
+	CodeUnbalancedParens    = "PAREN-UNBALANCED"      // operand parentheses do not balance
+	CodeInstreamOpen        = "INSTREAM-UNTERMINATED" // in-stream data runs to end of member
+	CodeSymbolUndefined     = "SYMBOL-UNDEFINED"
+	CodeProcNotFound        = "PROC-NOT-FOUND"
+	CodeIncludeNotFound     = "INCLUDE-NOT-FOUND"
+	CodeNestingTooDeep      = "NESTING-TOO-DEEP"
+	CodeCondSyntax          = "COND-SYNTAX"   // COND= or IF expression does not parse
+	CodeIfUnbalanced        = "IF-UNBALANCED" // ELSE/ENDIF without IF, IF without ENDIF
+	CodeReferbackUnknown    = "REFERBACK-UNRESOLVED"
+	CodeControlCardsMissing = "CONTROL-CARDS-NOT-FOUND" // dataset mirrored by a SYSIN library, member missing
+)
+
+// Diagnostics collects the problems found while parsing, in discovery order.
+type Diagnostics struct {
+	Items []ir.Diagnostic
+}
+
+func (d *Diagnostics) add(severity, code, file string, line int, format string, args ...any) {
+	d.Items = append(d.Items, ir.Diagnostic{
+		Severity: severity,
+		Code:     code,
+		File:     file,
+		Line:     line,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Errors returns the number of ERROR diagnostics.
+func (d Diagnostics) Errors() int {
+	n := 0
+	for _, it := range d.Items {
+		if it.Severity == ir.SeverityError {
+			n++
+		}
+	}
+	return n
+}
+
+// knownOps are the JCL operations readStatements accepts.
+var knownOps = map[string]bool{
+	"JOB": true, "EXEC": true, "DD": true, "PROC": true, "PEND": true,
+	"SET": true, "JCLLIB": true, "INCLUDE": true, "IF": true, "ELSE": true,
+	"ENDIF": true, "OUTPUT": true, "CNTL": true, "ENDCNTL": true,
+	"EXPORT": true, "XMIT": true, "COMMAND": true, "SCHEDULE": true,
+	"JOBGROUP": true, "ENDGROUP": true, "GJOB": true, "JOBSET": true,
+	"ENDSET": true, "SJOB": true, "AFTER": true, "BEFORE": true, "CONCURRENT": true,
+}
+
+// validName reports whether s is a valid name field: 1-8 alphanumeric or
+// national characters, not starting with a digit. Override names
+// (procstep.ddname) are checked part by part.
+func validName(s string) bool {
+	for _, part := range strings.Split(s, ".") {
+		if len(part) == 0 || len(part) > 8 || (part[0] >= '0' && part[0] <= '9') {
+			return false
+		}
+		for i := 0; i < len(part); i++ {
+			c := part[i]
+			if !(c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '@' || c == '#' || c == '$') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+var jobKeywords = keywordSet(`ADDRSPC BYTES CARDS CCSID CLASS COND DSENQSHR EMAIL
+	GDGBIAS GROUP JESLOG JOBRC LINES MEMLIMIT MSGCLASS MSGLEVEL NOTIFY PAGES
+	PASSWORD PERFORM PRTY RD REGION REGIONX RESTART SECLABEL SCHENV SYSAFF
+	SYSTEM TIME TYPRUN UJOBCORR USER`)
+
+var ddKeywords = keywordSet(`ACCODE AMP AVGREC BLKSIZE BLKSZLIM BURST CCSID
+	CHARS CHKPT CNTL COPIES DATACLAS DCB DDNAME DEST DISP DLM DSID DSKEYLBL DSN
+	DSNAME DSNTYPE DSORG EATTR EXPDT FCB FILEDATA FLASH FREE FREEVOL GDGORDER
+	HOLD KEYLABL1 KEYLABL2 KEYENCD1 KEYENCD2 KEYLEN KEYOFF LABEL LGSTREAM LIKE
+	LRECL MAXGENS MGMTCLAS MODIFY OUTLIM OUTPUT PATH PATHDISP PATHMODE PATHOPTS
+	PROTECT QNAME RECFM RECORG REFDD RETPD RLS ROACCESS SECMODEL SEGMENT SPACE
+	SPIN STORCLAS SUBSYS SYMBOLS SYMLIST SYSOUT TERM UCS UNIT VOL VOLUME
+	BFALN BFTEK BUFIN BUFL BUFMAX BUFNO BUFOFF BUFOUT BUFSIZE CODE CPRI CYLOFL
+	DEN DIAGNS EROPT FUNC GNCP INTVL LIMCT MODE NCP NTM OPTCD PCI PRTSP RESERVE
+	RKP STACK THRESH TRTCH`)
+
+func keywordSet(s string) map[string]bool {
+	m := map[string]bool{}
+	for _, k := range strings.Fields(s) {
+		m[k] = true
+	}
+	return m
+}
+
+// checkStatement reports unbalanced parentheses and unknown keywords on
+// JOB, EXEC PGM= and DD statements.
+func (x *expander) checkStatement(st statement) {
+	var known map[string]bool
+	switch st.Op {
+	case "JOB":
+		known = jobKeywords
+	case "DD":
+		known = ddKeywords
+	case "EXEC":
+		// EXEC procname (left unresolved) carries symbolic parameters.
+		if _, ok := splitParams(st.Operands).get("PGM"); !ok {
+			return
+		}
+	default:
+		return
+	}
+	if !balanced(st.Operands) {
+		x.errorf(st, CodeUnbalancedParens, "%s statement has unbalanced parentheses", st.Op)
+	}
+	for _, p := range splitParams(st.Operands) {
+		if p.Key == "" {
+			continue
+		}
+		key, _, _ := strings.Cut(p.Key, ".")
+		ok := known[key]
+		if st.Op == "EXEC" {
+			ok = key == "PGM" || execKeywords[key]
+		}
+		if !ok {
+			x.warnf(st, CodeUnknownKeyword, "unknown %s keyword %s", st.Op, p.Key)
+		}
+	}
+}
+
+// balanced reports whether the parentheses outside quoted strings balance.
+func balanced(s string) bool {
+	depth, inQuote := 0, false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\'':
+			inQuote = !inQuote
+		case inQuote:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
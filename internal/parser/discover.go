@@ -0,0 +1,179 @@
+package parser
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+// discovery finds the job members under the analyzed sources. Include and
+// exclude globs are matched case-insensitively against the base name, or
+// against the path relative to the source root when they contain a slash
+// ("**" spans directories). Library directories are never read as jobs.
+type discovery struct {
+	include, exclude []string
+	libDirs          map[string]bool // absolute proc/include/sysin library dirs
+	diags            *Diagnostics
+
+	files   map[string]string // real path -> first path it was reached by
+	members map[string]string // MEMBER -> first file holding it
+	dirs    map[string]bool   // real directories walked (symlink cycles)
+}
+
+func newDiscovery(opts Options, diags *Diagnostics) *discovery {
+	d := &discovery{
+		include: opts.Include,
+		exclude: opts.Exclude,
+		libDirs: map[string]bool{},
+		diags:   diags,
+		files:   map[string]string{},
+		members: map[string]string{},
+		dirs:    map[string]bool{},
+	}
+	if len(d.include) == 0 {
+		for _, ext := range inputExts {
+			d.include = append(d.include, "*"+ext)
+		}
+	}
+	for _, libs := range [][]ProcLib{opts.ProcLibs, opts.SysinLibs} {
+		for _, l := range libs {
+			if abs, err := filepath.Abs(l.Dir); err == nil {
+				d.libDirs[abs] = true
+			}
+		}
+	}
+	return d
+}
+
+// walk calls visit for every selected file under root, in lexical order.
+// A root naming a file is always visited.
+func (d *discovery) walk(root string, visit func(p string)) {
+	d.walkDir(root, root, visit)
+}
+
+func (d *discovery) walkDir(root, dir string, visit func(p string)) {
+	// WalkDir does not descend into a symlinked root, so walk the real
+	// directory and report paths under dir.
+	real, err := filepath.EvalSymlinks(dir)
+	if err != nil || d.dirs[real] {
+		return
+	}
+	d.dirs[real] = true
+	_ = filepath.WalkDir(real, func(rp string, e fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		p := dir
+		if sub, _ := filepath.Rel(real, rp); sub != "." {
+			p = filepath.Join(dir, sub)
+		}
+		rel := relPath(root, p)
+		if e.IsDir() {
+			if rp == real {
+				return nil
+			}
+			if abs, _ := filepath.Abs(p); d.libDirs[abs] || matchAny(d.exclude, rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if e.Type()&fs.ModeSymlink != 0 {
+			st, err := os.Stat(p)
+			if err != nil {
+				return nil
+			}
+			if st.IsDir() {
+				if abs, _ := filepath.Abs(p); !d.libDirs[abs] && !matchAny(d.exclude, rel) {
+					d.walkDir(root, p, visit)
+				}
+				return nil
+			}
+		}
+		if p != root && (!matchAny(d.include, rel) || matchAny(d.exclude, rel)) {
+			return nil
+		}
+		if d.unique(p) {
+			visit(p)
+		}
+		return nil
+	})
+}
+
+// unique reports whether p is a file not already reached through another
+// path (a symlink or overlapping source), and warns about member names
+// that appear in more than one file.
+func (d *discovery) unique(p string) bool {
+	real, err := filepath.EvalSymlinks(p)
+	if err != nil {
+		real = p
+	}
+	if first, ok := d.files[real]; ok {
+		d.diags.add(ir.SeverityWarning, CodeDuplicateInput, p, 0, "same file as %s; skipped", first)
+		return false
+	}
+	d.files[real] = p
+	member := strings.ToUpper(memberName(p))
+	if first, ok := d.members[member]; ok {
+		d.diags.add(ir.SeverityWarning, CodeDuplicateMember, p, 0, "member %s is also in %s", member, first)
+	} else {
+		d.members[member] = p
+	}
+	return true
+}
+
+// relPath is p relative to root with forward slashes.
+func relPath(root, p string) string {
+	rel, err := filepath.Rel(root, p)
+	if err != nil || rel == "." {
+		rel = filepath.Base(p)
+	}
+	return filepath.ToSlash(rel)
+}
+
+func matchAny(patterns []string, rel string) bool {
+	for _, pat := range patterns {
+		if matchGlob(pat, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches pattern against rel (slash-separated), ignoring case.
+func matchGlob(pattern, rel string) bool {
+	pattern = strings.ToLower(filepath.ToSlash(strings.TrimSpace(pattern)))
+	rel = strings.ToLower(rel)
+	if pattern == "" {
+		return false
+	}
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(rel))
+		return ok
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(rel, "/"))
+}
+
+func matchSegments(pat, segs []string) bool {
+	for len(pat) > 0 {
+		if pat[0] == "**" {
+			for i := 0; i <= len(segs); i++ {
+				if matchSegments(pat[1:], segs[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segs) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pat[0], segs[0]); !ok {
+			return false
+		}
+		pat, segs = pat[1:], segs[1:]
+	}
+	return len(segs) == 0
+}
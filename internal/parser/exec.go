@@ -0,0 +1,168 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+// Programs whose PARM is a comma-separated option list, and programs whose
+// PARM is a blank-separated command line.
+var (
+	commaParmPgms = map[string]bool{
+		"SORT": true, "ICEMAN": true, "DFSORT": true, "SYNCSORT": true, "ICETOOL": true,
+		"IDCAMS": true, "IGYCRCTL": true, "ASMA90": true, "IEWL": true, "HEWL": true, "IEWBLINK": true,
+	}
+	commandParmPgms = map[string]bool{
+		"IKJEFT01": true, "IKJEFT1A": true, "IKJEFT1B": true, "BPXBATCH": true,
+	}
+)
+
+// parseExec fills the EXEC statement parameters of step.
+func parseExec(step *ir.Step, ps params) {
+	get := func(key string) string {
+		v, _ := ps.get(key)
+		return strings.TrimSpace(v)
+	}
+	if v, ok := ps.get("PARM"); ok {
+		step.Parm = parmValue(v)
+		step.ParmArgs = tokenizeParm(step.Program, step.Parm)
+	}
+	step.ParmDD = strings.ToUpper(get("PARMDD"))
+	step.Region = strings.ToUpper(get("REGION"))
+	step.RegionKB = regionKB(step.Region)
+	step.Time = strings.ToUpper(get("TIME"))
+	step.TimeSeconds = timeSeconds(step.Time)
+	step.AddrSpc = strings.ToUpper(get("ADDRSPC"))
+	step.DynamNbr, _ = strconv.Atoi(get("DYNAMNBR"))
+	step.Acct = get("ACCT")
+}
+
+// parmValue returns the string passed to the program: PARM='A B' → A B,
+// PARM=(A,'B') → A,B.
+func parmValue(v string) string {
+	v = strings.TrimSpace(v)
+	if len(v) >= 2 && v[0] == '(' && v[len(v)-1] == ')' {
+		items := splitTop(v[1 : len(v)-1])
+		for i := range items {
+			items[i] = unquote(strings.TrimSpace(items[i]))
+		}
+		return strings.Join(items, ",")
+	}
+	return unquote(v)
+}
+
+// tokenizeParm splits a PARM string for programs with a known PARM syntax;
+// other programs get nil.
+func tokenizeParm(pgm, parm string) []string {
+	var toks []string
+	switch {
+	case commaParmPgms[pgm]:
+		toks = splitTop(parm)
+	case commandParmPgms[pgm]:
+		toks = splitBlanks(parm)
+	default:
+		return nil
+	}
+	var out []string
+	for _, t := range toks {
+		if t = strings.TrimSpace(t); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// splitBlanks splits s on blanks outside quotes and parentheses.
+func splitBlanks(s string) []string {
+	var (
+		out     []string
+		depth   int
+		inQuote bool
+		start   int
+	)
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case ch == '\'' || ch == '"':
+			inQuote = !inQuote
+		case inQuote:
+		case ch == '(':
+			depth++
+		case ch == ')':
+			if depth > 0 {
+				depth--
+			}
+		case ch == ' ' && depth == 0:
+			out = append(out, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(out, s[start:])
+}
+
+// regionKB converts REGION=nK / nM to kilobytes; 0K and 0M mean no limit
+// (-1). Returns 0 when not coded or not numeric.
+func regionKB(v string) int {
+	if len(v) < 2 {
+		return 0
+	}
+	n, err := strconv.Atoi(v[:len(v)-1])
+	if err != nil {
+		return 0
+	}
+	switch v[len(v)-1] {
+	case 'K':
+	case 'M':
+		n *= 1024
+	default:
+		return 0
+	}
+	if n == 0 {
+		return -1
+	}
+	return n
+}
+
+// timeSeconds converts TIME=min, TIME=(min,sec) or TIME=(,sec) to seconds;
+// NOLIMIT, MAXIMUM and 1440 mean no limit (-1). Returns 0 when not coded.
+func timeSeconds(v string) int {
+	sp := subparams(v)
+	if len(sp) == 0 {
+		return 0
+	}
+	switch first := strings.TrimSpace(sp[0]); first {
+	case "NOLIMIT", "MAXIMUM", "1440":
+		return -1
+	}
+	minutes, _ := strconv.Atoi(strings.TrimSpace(sp[0]))
+	secs := 0
+	if len(sp) > 1 {
+		secs, _ = strconv.Atoi(strings.TrimSpace(sp[1]))
+	}
+	return minutes*60 + secs
+}
+
+// finishStep moves the STEPLIB DD (with its concatenation) out of the
+// step's DDs into StepLib.
+func finishStep(step *ir.Step) {
+	for i, dd := range step.DD {
+		if dd.DDName != "STEPLIB" {
+			continue
+		}
+		step.StepLib = libraryDatasets(dd)
+		step.DD = append(step.DD[:i:i], step.DD[i+1:]...)
+		return
+	}
+}
+
+// libraryDatasets lists the datasets of a JOBLIB/STEPLIB concatenation.
+func libraryDatasets(dd ir.DD) []string {
+	var out []string
+	for _, m := range dd.Members() {
+		if m.Dataset != "" {
+			out = append(out, m.Dataset)
+		}
+	}
+	return out
+}
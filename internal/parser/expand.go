@@ -0,0 +1,478 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+// JES limits: procedures nest at most 15 levels, INCLUDE groups 15 levels.
+const maxNesting = 15
+
+// execKeywords are EXEC parameters that a PROC call may override; any other
+// keyword on EXEC procname is a symbolic parameter.
+var execKeywords = map[string]bool{
+	"ACCT": true, "ADDRSPC": true, "CCSID": true, "COND": true, "DPRTY": true,
+	"DYNAMNBR": true, "MEMLIMIT": true, "PARM": true, "PARMDD": true,
+	"PERFORM": true, "RD": true, "REGION": true, "REGIONX": true, "TIME": true,
+	"TVSMSG": true, "TVSAMCOM": true,
+}
+
+// expander resolves INCLUDE groups, JCLLIB ORDER and PROC calls for one job,
+// producing a flat statement stream in which every EXEC names a program
+// (or is an unresolved PROC call).
+type expander struct {
+	lib      *library
+	file     string
+	diags    *Diagnostics
+	order    []string               // JCLLIB ORDER, searched before proclibs
+	instream map[string][]statement // in-stream PROC name -> body (PROC..PEND)
+	system   symtab                 // site system symbols (&SYSUID, &LYYMMDD, ...)
+
+	calls      int // PROC calls seen
+	unresolved int // PROC calls that could not be expanded
+}
+
+func newExpander(lib *library, file string, diags *Diagnostics, system symtab) *expander {
+	return &expander{lib: lib, file: file, diags: diags, instream: map[string][]statement{}, system: system}
+}
+
+// warnf and errorf report a diagnostic at st, which may be the zero
+// statement for problems that belong to the member as a whole.
+func (x *expander) warnf(st statement, code, format string, args ...any) {
+	x.report(ir.SeverityWarning, st, code, format, args...)
+}
+
+func (x *expander) errorf(st statement, code, format string, args ...any) {
+	x.report(ir.SeverityError, st, code, format, args...)
+}
+
+func (x *expander) report(severity string, st statement, code, format string, args ...any) {
+	file := st.File
+	if file == "" {
+		file = x.file
+	}
+	x.diags.add(severity, code, file, st.Line, format, args...)
+}
+
+// expand flattens a job stream: INCLUDEs are spliced in, in-stream PROCs are
+// lifted out, symbols are substituted and every EXEC procname is replaced by
+// the PROC's steps.
+func (x *expander) expand(stmts []statement) []statement {
+	stmts = x.flatten(stmts, 0)
+
+	var body []statement
+	for i := 0; i < len(stmts); i++ {
+		st := stmts[i]
+		switch st.Op {
+		case "PROC":
+			j := i + 1
+			for j < len(stmts) && stmts[j].Op != "PEND" {
+				j++
+			}
+			if st.Name != "" {
+				x.instream[st.Name] = stmts[i:j]
+			}
+			i = j
+		case "PEND":
+			// stray PEND without PROC: nothing to close
+		default:
+			body = append(body, st)
+		}
+	}
+	return x.expandCalls(body, 0, x.system)
+}
+
+// flatten records JCLLIB ORDER and splices INCLUDE members in place.
+func (x *expander) flatten(stmts []statement, depth int) []statement {
+	var out []statement
+	for _, st := range stmts {
+		switch st.Op {
+		case "JCLLIB":
+			order, _ := splitParams(st.Operands).get("ORDER")
+			for _, ds := range subparams(order) {
+				x.order = append(x.order, strings.ToUpper(unquote(strings.TrimSpace(ds))))
+			}
+		case "INCLUDE":
+			member, _ := splitParams(st.Operands).get("MEMBER")
+			if depth >= maxNesting {
+				x.errorf(st, CodeNestingTooDeep, "INCLUDE %s nested too deeply", member)
+				continue
+			}
+			body, _, ok := x.lib.load(member, x.order)
+			if !ok {
+				x.warnf(st, CodeIncludeNotFound, "INCLUDE member %s not found", member)
+				continue
+			}
+			out = append(out, x.flatten(body, depth+1)...)
+		default:
+			out = append(out, st)
+		}
+	}
+	return out
+}
+
+// expandCalls substitutes symbols in scope (SET statements extend it as they
+// are met) and replaces each EXEC procname, with the override DDs that follow
+// it, by the expanded PROC steps.
+func (x *expander) expandCalls(stmts []statement, depth int, scope symtab) []statement {
+	var out []statement
+	for i := 0; i < len(stmts); i++ {
+		st := stmts[i]
+		x.resolve(&st, scope)
+		if st.Op == "SET" {
+			scope = scope.with(splitParams(st.Operands))
+			continue
+		}
+		if st.Op != "EXEC" {
+			out = append(out, st)
+			continue
+		}
+		ps := splitParams(st.Operands)
+		name := procName(ps)
+		if name == "" {
+			out = append(out, st)
+			continue
+		}
+		var overrides []statement
+		j := i + 1
+		for ; j < len(stmts) && stmts[j].Op == "DD"; j++ {
+			ov := stmts[j]
+			x.resolve(&ov, scope)
+			overrides = append(overrides, ov)
+		}
+		out = append(out, x.call(st, name, ps, overrides, depth, scope)...)
+		i = j - 1
+	}
+	return out
+}
+
+// resolve substitutes symbols in a statement's operands, keeping the coded
+// text in Raw. Undefined symbols are reported unless they are the old-style
+// &NAME temporary dataset form.
+func (x *expander) resolve(st *statement, scope symtab) {
+	// In IF expressions & is the AND operator, not a symbol.
+	if st.Raw != "" || st.Op == "IF" || strings.IndexByte(st.Operands, '&') == -1 {
+		return
+	}
+	st.Raw = st.Operands
+	var undefined []string
+	st.Operands, undefined = substitute(st.Operands, scope)
+	seen := map[string]bool{}
+	for _, name := range undefined {
+		if seen[name] || tempDSN(st.Raw, name) {
+			continue
+		}
+		if name == "SYSUID" && st.Op == "JOB" {
+			// JES substitutes the submitter's user ID on the JOB statement.
+			continue
+		}
+		seen[name] = true
+		x.warnf(*st, CodeSymbolUndefined, "symbol &%s is not defined", name)
+	}
+}
+
+// call expands one PROC invocation. The PROC's symbols are the caller's scope
+// overlaid with the PROC statement defaults and then the EXEC overrides.
+func (x *expander) call(exec statement, name string, ps params, overrides []statement, depth int, scope symtab) []statement {
+	x.calls++
+	body, ok := x.procBody(name)
+	if !ok || depth >= maxNesting {
+		x.unresolved++
+		if ok {
+			x.errorf(exec, CodeNestingTooDeep, "PROC %s nested too deeply", name)
+		} else {
+			x.warnf(exec, CodeProcNotFound, "PROC %s not found (in-stream, JCLLIB or proclibs)", name)
+		}
+		exec.Proc = name
+		return append([]statement{exec}, overrides...)
+	}
+
+	// Drop the PROC statement itself (keeping its defaults), then expand
+	// nested calls in the PROC's scope.
+	procScope := scope
+	var inner []statement
+	for _, st := range body {
+		switch st.Op {
+		case "PROC":
+			defaults, _ := substitute(st.Operands, scope)
+			procScope = procScope.with(splitParams(defaults))
+		case "PEND":
+		default:
+			inner = append(inner, st)
+		}
+	}
+	procScope = procScope.with(symbolicOverrides(ps))
+	inner = x.expandCalls(x.flatten(inner, depth+1), depth+1, procScope)
+
+	groups := groupSteps(inner)
+	for gi := range groups {
+		g := &groups[gi]
+		ex := &g.stmts[0]
+		if ex.ProcStep == "" {
+			ex.ProcStep = ex.Name
+			if ex.Proc == "" {
+				ex.Proc = name
+			}
+		}
+		ex.Name = qualify(exec.Name, ex.ProcStep)
+	}
+	applyExecOverrides(groups, ps)
+	applyDDOverrides(groups, overrides)
+
+	var out []statement
+	for _, g := range groups {
+		out = append(out, g.stmts...)
+	}
+	return out
+}
+
+// procBody looks up an in-stream PROC first, then the cataloged libraries.
+func (x *expander) procBody(name string) ([]statement, bool) {
+	if body, ok := x.instream[name]; ok {
+		return body, true
+	}
+	body, _, ok := x.lib.load(name, x.order)
+	return body, ok
+}
+
+// procName returns the PROC invoked by an EXEC statement, or "" for EXEC PGM=.
+func procName(ps params) string {
+	if _, ok := ps.get("PGM"); ok {
+		return ""
+	}
+	if v, ok := ps.get("PROC"); ok {
+		return strings.ToUpper(v)
+	}
+	return strings.ToUpper(ps.positional(0))
+}
+
+// symbolicOverrides returns the keywords of EXEC procname that assign
+// symbolic parameters rather than override EXEC parameters.
+func symbolicOverrides(ps params) params {
+	var out params
+	for _, p := range ps {
+		key := p.Key
+		if i := strings.IndexByte(key, '.'); i != -1 {
+			key = key[:i]
+		}
+		if key == "" || key == "PROC" || execKeywords[key] {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+func qualify(stepName, procStep string) string {
+	if stepName == "" {
+		return procStep
+	}
+	return stepName + "." + procStep
+}
+
+// stepGroup is an EXEC statement with the statements that follow it.
+type stepGroup struct {
+	stmts []statement
+}
+
+// groupSteps splits a PROC body into step groups. Statements before the
+// first EXEC are dropped (PROC bodies start with EXEC after PROC/SET).
+func groupSteps(stmts []statement) []stepGroup {
+	var out []stepGroup
+	for _, st := range stmts {
+		if st.Op == "EXEC" {
+			out = append(out, stepGroup{stmts: []statement{st}})
+			continue
+		}
+		if len(out) > 0 {
+			out[len(out)-1].stmts = append(out[len(out)-1].stmts, st)
+		}
+	}
+	return out
+}
+
+// applyExecOverrides applies KEY.procstep=value and bare EXEC keywords from
+// the invoking EXEC. A bare PARM applies to the first step and nullifies PARM
+// on the others; other bare keywords apply to every step.
+func applyExecOverrides(groups []stepGroup, call params) {
+	for _, p := range call {
+		if p.Key == "" || p.Key == "PROC" {
+			continue
+		}
+		key, target := p.Key, ""
+		if i := strings.IndexByte(key, '.'); i != -1 {
+			key, target = key[:i], key[i+1:]
+		}
+		if !execKeywords[key] {
+			continue
+		}
+		for gi := range groups {
+			ex := &groups[gi].stmts[0]
+			switch {
+			case target != "":
+				if ex.ProcStep != target {
+					continue
+				}
+				ex.Operands = setParam(ex.Operands, key, p.Value)
+			case key == "PARM" && gi > 0:
+				ex.Operands = setParam(ex.Operands, key, "")
+			default:
+				ex.Operands = setParam(ex.Operands, key, p.Value)
+			}
+		}
+	}
+}
+
+// applyDDOverrides merges //procstep.ddname DD overrides into the PROC steps.
+// Unqualified DDs apply to the first step; unknown DD names are added to the
+// step; unnamed DDs follow the previous override (concatenation).
+func applyDDOverrides(groups []stepGroup, overrides []statement) {
+	if len(groups) == 0 {
+		return
+	}
+	target, lastIdx := 0, -1
+	for _, ov := range overrides {
+		if ov.Name == "" {
+			if lastIdx >= 0 {
+				g := &groups[target]
+				lastIdx++
+				g.stmts = insertAt(g.stmts, lastIdx, ov)
+			}
+			continue
+		}
+		step, ddname := "", ov.Name
+		if i := strings.IndexByte(ddname, '.'); i != -1 {
+			step, ddname = ddname[:i], ddname[i+1:]
+		}
+		target = 0
+		if step != "" {
+			target = -1
+			for gi := range groups {
+				if groups[gi].stmts[0].ProcStep == step {
+					target = gi
+					break
+				}
+			}
+			if target == -1 {
+				lastIdx = -1
+				continue
+			}
+		}
+		ov.Name = ddname
+		g := &groups[target]
+		lastIdx = -1
+		for si := 1; si < len(g.stmts); si++ {
+			if g.stmts[si].Op == "DD" && g.stmts[si].Name == ddname {
+				g.stmts[si] = mergeDD(g.stmts[si], ov)
+				lastIdx = si
+				break
+			}
+		}
+		if lastIdx == -1 {
+			g.stmts = append(g.stmts, ov)
+			lastIdx = len(g.stmts) - 1
+		}
+	}
+}
+
+func insertAt(s []statement, i int, st statement) []statement {
+	s = append(s, statement{})
+	copy(s[i+1:], s[i:])
+	s[i] = st
+	return s
+}
+
+// mergeDD overrides a PROC DD with the parameters coded on the override:
+// keywords replace (or, when coded empty, nullify) the PROC's, positional
+// parameters (DUMMY, *, DATA) replace the PROC's, and coding DSN on a DUMMY
+// DD removes DUMMY.
+func mergeDD(base, over statement) statement {
+	baseRaw, overRaw := base.Raw, over.Raw
+	if baseRaw == "" {
+		baseRaw = base.Operands
+	}
+	if overRaw == "" {
+		overRaw = over.Operands
+	}
+	base.Operands = mergeOperands(base.Operands, over.Operands)
+	if base.Raw != "" || over.Raw != "" {
+		base.Raw = mergeOperands(baseRaw, overRaw)
+	}
+	if len(over.Data) > 0 || isInstream(over.Operands) {
+		base.Data, base.DataEnd = over.Data, over.DataEnd
+	}
+	return base
+}
+
+func mergeOperands(baseOps, overOps string) string {
+	bp, op := splitParams(baseOps), splitParams(overOps)
+
+	var pos params
+	for _, p := range op {
+		if p.Key == "" {
+			pos = append(pos, p)
+		}
+	}
+	_, hasDSN := op.get("DSN")
+	if _, ok := op.get("DSNAME"); ok {
+		hasDSN = true
+	}
+	if len(pos) == 0 {
+		for _, p := range bp {
+			if p.Key != "" {
+				continue
+			}
+			if hasDSN && strings.EqualFold(p.Value, "DUMMY") {
+				continue
+			}
+			pos = append(pos, p)
+		}
+	}
+
+	merged := pos
+	for _, p := range bp {
+		if p.Key == "" {
+			continue
+		}
+		if v, ok := op.get(p.Key); ok {
+			if v != "" {
+				merged = append(merged, param{Key: p.Key, Value: v})
+			}
+			continue
+		}
+		merged = append(merged, p)
+	}
+	for _, p := range op {
+		if p.Key == "" {
+			continue
+		}
+		if _, ok := bp.get(p.Key); !ok && p.Value != "" {
+			merged = append(merged, p)
+		}
+	}
+
+	return merged.String()
+}
+
+// setParam replaces (or appends) keyword key in an operand field; an empty
+// value removes it.
+func setParam(operands, key, value string) string {
+	ps := splitParams(operands)
+	var out params
+	done := false
+	for _, p := range ps {
+		if p.Key == key {
+			if !done && value != "" {
+				out = append(out, param{Key: key, Value: value})
+			}
+			done = true
+			continue
+		}
+		out = append(out, p)
+	}
+	if !done && value != "" {
+		out = append(out, param{Key: key, Value: value})
+	}
+	return out.String()
+}
@@ -0,0 +1,178 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+// ProcLib maps a local directory of members to the PDS it mirrors. Dataset is
+// optional; when set, JCLLIB ORDER entries naming it search Dir first.
+type ProcLib struct {
+	Dir     string
+	Dataset string
+}
+
+// memberExts are the suffixes tried when matching a member name to a file.
+var memberExts = []string{"", ".jcl", ".proc", ".prc", ".inc", ".txt", ".cntl"}
+
+// library resolves PROC and INCLUDE members from the configured proclibs.
+// Members are read once per Parse and cached; the card diagnostics of the
+// members read are kept in diags, since which job reads a member first
+// depends on scheduling.
+type library struct {
+	libs     []ProcLib
+	sysin    []ProcLib // control-card (SYSIN) libraries
+	encoding string
+
+	mu    sync.Mutex
+	index map[string]map[string]string // dir -> MEMBER -> file path
+	cache map[string][]statement       // file path -> statements
+	cards map[string]*string           // file path -> control-card records
+	diags Diagnostics
+}
+
+func newLibrary(libs, sysin []ProcLib, encoding string) *library {
+	return &library{
+		libs:     libs,
+		sysin:    sysin,
+		encoding: encoding,
+		cards:    map[string]*string{},
+		index:    map[string]map[string]string{},
+		cache:    map[string][]statement{},
+	}
+}
+
+// find returns the file holding member, searching the JCLLIB ORDER libraries
+// first and then every configured proclib in order.
+func (l *library) find(member string, order []string) (string, bool) {
+	member = strings.ToUpper(strings.TrimSpace(member))
+	if member == "" {
+		return "", false
+	}
+	for _, dir := range l.searchDirs(order) {
+		if p, ok := l.members(dir)[member]; ok {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// load returns the statements of member, or false if it cannot be found or
+// read.
+func (l *library) load(member string, order []string) ([]statement, string, bool) {
+	p, ok := l.find(member, order)
+	if !ok {
+		return nil, "", false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if stmts, ok := l.cache[p]; ok {
+		return stmts, p, stmts != nil
+	}
+	diags := &l.diags
+	l.cache[p] = nil // failed reads are reported once
+	data, err := os.ReadFile(p)
+	if err != nil {
+		diags.add(ir.SeverityError, CodeReadError, p, 0, "%v", err)
+		return nil, p, false
+	}
+	// A proclib member is a single file: archives are not searched.
+	srcs, err := decodeInput(p, data, l.encoding)
+	if err == nil && len(srcs) == 0 {
+		err = fmt.Errorf("%s: no members", p)
+	}
+	if err != nil {
+		diags.add(ir.SeverityError, CodeReadError, p, 0, "%v", err)
+		return nil, p, false
+	}
+	stmts, err := readStatements(bytes.NewReader(srcs[0].Text), p, diags)
+	if stmts == nil {
+		stmts = []statement{}
+	}
+	if err != nil {
+		diags.add(ir.SeverityError, CodeReadError, p, 0, "%v", err)
+		return nil, p, false
+	}
+	l.cache[p] = stmts
+	return stmts, p, true
+}
+
+func (l *library) searchDirs(order []string) []string {
+	var dirs []string
+	seen := map[string]bool{}
+	add := func(d string) {
+		if d != "" && !seen[d] {
+			seen[d] = true
+			dirs = append(dirs, d)
+		}
+	}
+	for _, ds := range order {
+		for _, lib := range l.libs {
+			if lib.Dataset != "" && strings.EqualFold(lib.Dataset, ds) {
+				add(lib.Dir)
+			}
+		}
+	}
+	for _, lib := range l.libs {
+		add(lib.Dir)
+	}
+	return dirs
+}
+
+// members indexes a directory by upper-cased member name (extension dropped).
+func (l *library) members(dir string) map[string]string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if m, ok := l.index[dir]; ok {
+		return m
+	}
+	m := map[string]string{}
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		ext := strings.ToLower(filepath.Ext(name))
+		if !knownExt(ext) {
+			continue
+		}
+		key := strings.ToUpper(strings.TrimSuffix(name, filepath.Ext(name)))
+		if _, dup := m[key]; !dup || ext == "" {
+			m[key] = filepath.Join(dir, name)
+		}
+	}
+	l.index[dir] = m
+	return m
+}
+
+func knownExt(ext string) bool {
+	for _, e := range memberExts {
+		if e == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// diagnostics returns the diagnostics of the members read, ordered by file
+// and line.
+func (l *library) diagnostics() []ir.Diagnostic {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := append([]ir.Diagnostic(nil), l.diags.Items...)
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].File != out[j].File {
+			return out[i].File < out[j].File
+		}
+		return out[i].Line < out[j].Line
+	})
+	return out
+}
@@ -0,0 +1,132 @@
+package parser
+
+import "strings"
+
+// param is one top-level operand: a keyword parameter (KEY=VALUE) or a
+// positional parameter (Key empty). Values keep their parentheses and quotes.
+type param struct {
+	Key   string
+	Value string
+}
+
+type params []param
+
+// splitParams splits an operand field on top-level commas.
+func splitParams(s string) params {
+	var out params
+	for _, item := range splitTop(s) {
+		if item == "" {
+			continue
+		}
+		if i := keywordEnd(item); i > 0 {
+			out = append(out, param{Key: strings.ToUpper(item[:i]), Value: item[i+1:]})
+			continue
+		}
+		out = append(out, param{Value: item})
+	}
+	return out
+}
+
+// get returns the value of the first keyword parameter named key.
+func (ps params) get(key string) (string, bool) {
+	for _, p := range ps {
+		if p.Key == key {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+// positional returns the i-th positional parameter, or "".
+func (ps params) positional(i int) string {
+	n := 0
+	for _, p := range ps {
+		if p.Key != "" {
+			continue
+		}
+		if n == i {
+			return p.Value
+		}
+		n++
+	}
+	return ""
+}
+
+// String renders params back into an operand field.
+func (ps params) String() string {
+	parts := make([]string, len(ps))
+	for i, p := range ps {
+		if p.Key == "" {
+			parts[i] = p.Value
+		} else {
+			parts[i] = p.Key + "=" + p.Value
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// keywordEnd returns the index of the '=' ending a leading keyword, or -1.
+func keywordEnd(item string) int {
+	for i := 0; i < len(item); i++ {
+		ch := item[i]
+		switch {
+		case ch == '=':
+			return i
+		case ch >= 'A' && ch <= 'Z', ch >= 'a' && ch <= 'z', ch >= '0' && ch <= '9',
+			ch == '.', ch == '#', ch == '@', ch == '$', ch == '&':
+			continue
+		default:
+			return -1
+		}
+	}
+	return -1
+}
+
+// splitTop splits s on commas that are outside parentheses and quotes.
+// Empty positions are kept so that "(,CATLG)" lists stay aligned.
+func splitTop(s string) []string {
+	var (
+		out     []string
+		depth   int
+		inQuote bool
+		start   int
+	)
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case ch == '\'':
+			inQuote = !inQuote
+		case inQuote:
+		case ch == '(':
+			depth++
+		case ch == ')':
+			if depth > 0 {
+				depth--
+			}
+		case ch == ',' && depth == 0:
+			out = append(out, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(out, s[start:])
+}
+
+// subparams strips one level of enclosing parentheses and splits the list.
+// A bare value yields a one-element list.
+func subparams(v string) []string {
+	v = strings.TrimSpace(v)
+	if len(v) >= 2 && v[0] == '(' && v[len(v)-1] == ')' {
+		return splitTop(v[1 : len(v)-1])
+	}
+	if v == "" {
+		return nil
+	}
+	return []string{v}
+}
+
+// unquote removes enclosing apostrophes and collapses doubled ones.
+func unquote(v string) string {
+	if len(v) >= 2 && v[0] == '\'' && v[len(v)-1] == '\'' {
+		return strings.ReplaceAll(v[1:len(v)-1], "''", "'")
+	}
+	return v
+}
@@ -1,214 +1,366 @@
 package parser
 
 import (
-	"bufio"
+	"bytes"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/shared"
 )
 
-type Diagnostics struct {
-	Warnings []string
+// Options controls which members are read as jobs and how members outside
+// the analyzed directories are resolved.
+type Options struct {
+	ProcLibs  []ProcLib         // cataloged PROC / INCLUDE libraries, in search order
+	SysinLibs []ProcLib         // libraries of control-card (SYSIN) members
+	Symbols   map[string]string // site system symbols (SYSUID, LYYMMDD, ...), without '&'
+	Encoding  string            // input encoding: auto (default), ascii, ibm1047, ibm037
+	Include   []string          // globs selecting job members; default: the inputExts suffixes
+	Exclude   []string          // globs for files and directories to skip
+	Workers   int               // files parsed concurrently; GOMAXPROCS when <= 0
 }
 
+// inputExts are the file suffixes read when Options.Include is empty: JCL
+// text, and binary exports (TSO TRANSMIT archives, IEBCOPY unloads, FB-80
+// EBCDIC transfers).
+var inputExts = []string{".jcl", ".txt", ".xmi", ".xmit", ".unload", ".bin", ".ebc"}
+
 func Parse(path string) (ir.Run, Diagnostics) {
+	return ParseWithOptions(path, Options{})
+}
+
+func ParseWithOptions(path string, opts Options) (ir.Run, Diagnostics) {
+	return ParseSources([]string{path}, opts)
+}
+
+// ParseSources parses the job members found under each path. Files are
+// parsed on Options.Workers goroutines; jobs and diagnostics keep discovery
+// order. Files reached twice (through symlinks or overlapping sources) are
+// parsed once.
+func ParseSources(paths []string, opts Options) (ir.Run, Diagnostics) {
 	var run ir.Run
 	run.IRVersion = ir.Version
-	run.Source = filepath.Clean(path)
+	var cleaned []string
+	for _, p := range paths {
+		cleaned = append(cleaned, filepath.Clean(p))
+	}
+	run.Source = strings.Join(cleaned, ", ")
 	diags := Diagnostics{}
+	lib := newLibrary(opts.ProcLibs, opts.SysinLibs, opts.Encoding)
+	system := symtab{}
+	for k, v := range opts.Symbols {
+		system[strings.ToUpper(strings.TrimPrefix(strings.TrimSpace(k), "&"))] = v
+	}
 
-	_ = filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
-		if err != nil || d.IsDir() {
-			return nil
-		}
-		name := strings.ToLower(d.Name())
-		if !strings.HasSuffix(name, ".jcl") && !strings.HasSuffix(name, ".txt") {
-			return nil
+	disc := newDiscovery(opts, &diags)
+	var files []string
+	for _, root := range cleaned {
+		if _, err := os.Stat(root); err != nil {
+			diags.add(ir.SeverityError, CodeReadError, root, 0, "%v", err)
+			continue
 		}
-		job, perr := parseFile(p)
-		if perr == nil && len(job.Steps) > 0 {
-			run.Jobs = append(run.Jobs, job)
+		disc.walk(root, func(p string) { files = append(files, p) })
+	}
+
+	type result struct {
+		jobs  []ir.Job
+		diags Diagnostics
+	}
+	results := make([]result, len(files))
+	shared.ParallelFor(len(files), opts.Workers, func(i int) {
+		r := &results[i]
+		// Keep whatever was parsed before a read error and move on.
+		jobs, err := parseFile(files[i], opts.Encoding, lib, system, &r.diags)
+		if err != nil {
+			r.diags.add(ir.SeverityError, CodeReadError, files[i], 0, "%v", err)
 		}
-		return nil
+		r.jobs = jobs
 	})
+	for _, r := range results {
+		diags.Items = append(diags.Items, r.diags.Items...)
+		for _, job := range r.jobs {
+			if len(job.Steps) > 0 {
+				run.Jobs = append(run.Jobs, job)
+			}
+		}
+	}
+	diags.Items = append(diags.Items, lib.diagnostics()...)
 
 	if len(run.Jobs) == 0 {
-		diags.Warnings = append(diags.Warnings, "no JCL-like files found or no steps parsed")
+		diags.add(ir.SeverityWarning, CodeNoInput, run.Source, 0, "no JCL-like files found or no steps parsed")
 	}
+	run.Diagnostics = diags.Items
 	return run, diags
 }
 
-func parseFile(p string) (ir.Job, error) {
-	f, err := os.Open(p)
+// parseFile decodes one input file and parses every job in each of its
+// members. Each JOB statement starts a new job; statements ahead of the
+// first JOB statement form a job named after the member.
+func parseFile(p, encoding string, lib *library, system symtab, diags *Diagnostics) ([]ir.Job, error) {
+	data, err := os.ReadFile(p)
 	if err != nil {
-		return ir.Job{}, err
+		return nil, err
+	}
+	srcs, err := decodeInput(p, data, encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []ir.Job
+	for _, src := range srcs {
+		stmts, rerr := readStatements(bytes.NewReader(src.Text), src.Name, diags)
+		if rerr != nil {
+			err = rerr
+		}
+		for _, seg := range splitJobs(stmts) {
+			x := newExpander(lib, src.Name, diags, system)
+			job := buildJob(x, x.expand(seg), src.Member)
+			job.Loc = location(seg[0])
+			extendLocation(job.Loc, seg[len(seg)-1])
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, err
+}
+
+// splitJobs cuts a member's statements at each JOB statement.
+func splitJobs(stmts []statement) [][]statement {
+	var out [][]statement
+	start := 0
+	for i, st := range stmts {
+		if st.Op == "JOB" && i > start {
+			out = append(out, stmts[start:i])
+			start = i
+		}
+	}
+	if start < len(stmts) {
+		out = append(out, stmts[start:])
 	}
-	defer f.Close()
+	return out
+}
 
-	job := ir.Job{Name: strings.TrimSuffix(filepath.Base(p), filepath.Ext(p))}
+// buildJob turns one job's expanded statements into an ir.Job.
+func buildJob(x *expander, stmts []statement, name string) ir.Job {
+	job := ir.Job{Name: name}
+	job.ProcsResolved = x.unresolved == 0
 	var steps []ir.Step
 	var cur *ir.Step
-	var sysinCapturing bool
-	var sysinBuf strings.Builder
-
-	sc := bufio.NewScanner(f)
-	for sc.Scan() {
-		line := strings.TrimRight(sc.Text(), "\r\n")
-
-		// Capture inline SYSIN (between "DD *" and "/*")
-		if sysinCapturing {
-			if strings.TrimSpace(line) == "/*" {
-				sysinCapturing = false
-				if cur != nil {
-					for i := len(cur.DD) - 1; i >= 0; i-- {
-						if strings.EqualFold(cur.DD[i].DDName, "SYSIN") {
-							cur.DD[i].Content = sysinBuf.String()
-							break
-						}
-					}
+	var ifs []ir.IfClause   // open IF constructs, outermost first
+	var ifStmts []statement // their IF statements
+	ifCount := 0
+
+	for _, st := range stmts {
+		x.checkStatement(st)
+		switch st.Op {
+		case "JOB":
+			parseJob(&job, st)
+
+		case "/*JOBPARM":
+			if job.JobParm == nil {
+				job.JobParm = map[string]string{}
+			}
+			operands, _, _ := strings.Cut(st.Operands, " ")
+			for _, p := range splitParams(operands) {
+				if p.Key != "" {
+					job.JobParm[p.Key] = p.Value
 				}
+			}
+
+		case "/*ROUTE":
+			job.Route = append(job.Route, st.Operands)
+
+		case "IF":
+			ifCount++
+			c := ir.IfClause{ID: ifCount, Name: st.Name, Text: st.Operands}
+			expr, perr := parseIfExpr(st.Operands)
+			if perr != nil {
+				x.errorf(st, CodeCondSyntax, "%v", perr)
+			}
+			c.Expr = expr
+			ifs = append(ifs, c)
+			ifStmts = append(ifStmts, st)
+
+		case "ELSE":
+			if len(ifs) == 0 {
+				x.errorf(st, CodeIfUnbalanced, "ELSE without IF")
 				continue
 			}
-			sysinBuf.WriteString(line)
-			sysinBuf.WriteByte('\n')
-			continue
-		}
+			ifs[len(ifs)-1].Else = true
 
-		trim := strings.TrimSpace(line)
-		if !strings.HasPrefix(trim, "//") {
-			continue
-		}
-		card := strings.TrimSpace(trim[2:]) // after //
+		case "ENDIF":
+			if len(ifs) == 0 {
+				x.errorf(st, CodeIfUnbalanced, "ENDIF without IF")
+				continue
+			}
+			ifs = ifs[:len(ifs)-1]
+			ifStmts = ifStmts[:len(ifStmts)-1]
 
-		// New step: //<STEP> EXEC PGM=...
-		if idx := strings.Index(card, "EXEC"); idx != -1 && strings.Contains(strings.ToUpper(card), "PGM=") {
+		case "EXEC":
+			// New step: //<STEP> EXEC PGM=... (or an unresolved PROC call)
+			ps := splitParams(st.Operands)
+			pgm, ok := ps.get("PGM")
+			if !ok {
+				pgm = "UNKNOWN"
+			}
 			if cur != nil {
 				steps = append(steps, *cur)
 			}
-			stepName := firstField(card)
-			pgm := parsePGM(card)
-			cond := parseCOND(card)
-
+			stepName := st.Name
+			if stepName == "" {
+				stepName = "STEP"
+			}
+			cond, _ := ps.get("COND")
 			cur = &ir.Step{
 				Name:       stepName,
 				Program:    strings.ToUpper(pgm),
 				Ordinal:    len(steps) + 1,
 				Conditions: cond,
+				Proc:       st.Proc,
+				ProcStep:   st.ProcStep,
+				Loc:        location(st),
 			}
-			continue
-		}
-
-		// DD statement: //<DDNAME> DD ...
-		if idx := strings.Index(card, "DD "); idx != -1 {
-			if cur == nil {
-				cur = &ir.Step{Name: "STEP1", Program: "UNKNOWN", Ordinal: len(steps) + 1}
-			}
-			ddname := strings.ToUpper(strings.TrimSpace(card[:idx]))
-			rest := strings.TrimSpace(card[idx+3:])
-			upper := strings.ToUpper(rest)
-
-			dd := ir.DD{DDName: ddname}
-
-			// SYSIN DD *  → start capture
-			if ddname == "SYSIN" && strings.HasPrefix(strings.TrimSpace(rest), "*") {
-				sysinCapturing = true
-				sysinBuf.Reset()
-				cur.DD = append(cur.DD, dd)
-				continue
+			if sc, cerr := parseCond(cond); cerr != nil {
+				x.errorf(st, CodeCondSyntax, "step %s: %v", stepName, cerr)
+			} else {
+				cur.Cond = sc
 			}
-			// SYSIN DD DUMMY
-			if ddname == "SYSIN" && strings.HasPrefix(upper, "DUMMY") {
-				dd.Content = "DUMMY"
+			if len(ifs) > 0 {
+				cur.When = append([]ir.IfClause(nil), ifs...)
 			}
+			parseExec(cur, ps)
 
-			// DSN=
-			if i := strings.Index(upper, "DSN="); i != -1 {
-				val := rest[i+4:]
-				end := indexAny(val, ", ")
-				if end == -1 {
-					end = len(val)
-				}
-				dd.Dataset = strings.TrimSpace(val[:end])
-			}
-			// DISP=
-			if i := strings.Index(upper, "DISP="); i != -1 {
-				val := rest[i+5:]
-				if strings.HasPrefix(val, "(") {
-					if j := strings.Index(val, ")"); j != -1 {
-						dd.DISP = strings.TrimSpace(val[:j+1])
-					}
-				} else {
-					end := indexAny(val, ", ")
-					if end == -1 {
-						end = len(val)
+		case "DD":
+			// DD statement: //<DDNAME> DD ...
+			dd := parseDD(st)
+			if cur == nil {
+				// JOBLIB and its concatenation precede the first EXEC.
+				if dd.DDName == "JOBLIB" || (dd.DDName == "" && len(job.JobLib) > 0) {
+					if dd.Dataset != "" {
+						job.JobLib = append(job.JobLib, dd.Dataset)
 					}
-					dd.DISP = strings.TrimSpace(val[:end])
+					continue
 				}
+				cur = &ir.Step{Name: "STEP1", Program: "UNKNOWN", Ordinal: len(steps) + 1, Loc: location(st)}
 			}
-			// SPACE= (raw capture for now)
-			if i := strings.Index(upper, "SPACE="); i != -1 {
-				val := strings.TrimSpace(rest[i+6:])
-				end := indexAny(val, " ")
-				if end == -1 {
-					end = len(val)
-				}
-				dd.Space = strings.TrimRight(val[:end], ",")
+			extendLocation(cur.Loc, st)
+			if dd.DDName == "" && len(cur.DD) > 0 {
+				// Unnamed DD: next member of the preceding concatenation.
+				head := &cur.DD[len(cur.DD)-1]
+				dd.DDName = head.DDName
+				head.Concat = append(head.Concat, dd)
+				continue
 			}
-
 			cur.DD = append(cur.DD, dd)
-			continue
 		}
 	}
 	if cur != nil {
 		steps = append(steps, *cur)
 	}
+	for _, st := range ifStmts {
+		x.errorf(st, CodeIfUnbalanced, "IF without ENDIF")
+	}
+	resolveReferbacks(x, steps)
+	loadControlCards(x, steps)
+	for i := range steps {
+		finishStep(&steps[i])
+	}
 	job.Steps = steps
-	return job, sc.Err()
+	return job
 }
 
-func firstField(s string) string {
-	fs := strings.Fields(s)
-	if len(fs) > 0 {
-		return fs[0]
+// parseJob fills the job from its JOB statement: accounting information and
+// programmer name are positional, the rest are keywords.
+func parseJob(job *ir.Job, st statement) {
+	if st.Name != "" {
+		job.Name = st.Name
+	}
+	ps := splitParams(st.Operands)
+	job.Accounting = ps.positional(0)
+	job.Programmer = unquote(ps.positional(1))
+	get := func(key string) string {
+		v, _ := ps.get(key)
+		return v
+	}
+	job.Class = strings.ToUpper(get("CLASS"))
+	job.MsgClass = strings.ToUpper(get("MSGCLASS"))
+	job.Notify = strings.ToUpper(get("NOTIFY"))
+	job.Region = strings.ToUpper(get("REGION"))
+	job.Time = strings.ToUpper(get("TIME"))
+	job.TypRun = strings.ToUpper(get("TYPRUN"))
+	job.Restart = strings.ToUpper(get("RESTART"))
+	job.User = strings.ToUpper(get("USER"))
+
+	switch {
+	case job.User != "":
+		job.Owner = job.User
+	case job.Notify != "" && !strings.HasPrefix(job.Notify, "&"):
+		job.Owner = job.Notify
 	}
-	return "STEP"
 }
 
-func parsePGM(card string) string {
-	u := strings.ToUpper(card)
-	i := strings.Index(u, "PGM=")
-	if i == -1 {
-		return "UNKNOWN"
+// parseDD extracts the DD fields the rules consume from one DD statement.
+func parseDD(st statement) ir.DD {
+	ps := splitParams(st.Operands)
+	dd := ir.DD{DDName: st.Name, Loc: location(st)}
+
+	switch form := strings.ToUpper(ps.positional(0)); form {
+	case "*", "DATA":
+		// DD * / DD DATA → in-stream records, whatever the DD name
+		dd.Content = joinData(st.Data)
+		dd.Instream = form
+		dd.Delimiter = st.DataEnd
+	case "DUMMY":
+		// SYSIN DD DUMMY
+		if dd.DDName == "SYSIN" {
+			dd.Content = "DUMMY"
+		}
+	}
+
+	if v, ok := ps.get("DSN"); ok {
+		dd.Dataset = v
+	} else if v, ok := ps.get("DSNAME"); ok {
+		dd.Dataset = v
 	}
-	val := card[i+4:]
-	end := indexAny(val, ", ")
-	if end == -1 {
-		end = len(val)
+	if st.Raw != "" {
+		raw := splitParams(st.Raw)
+		v, ok := raw.get("DSN")
+		if !ok {
+			v, _ = raw.get("DSNAME")
+		}
+		if v != dd.Dataset {
+			dd.DatasetRaw = v
+		}
 	}
-	return strings.Trim(strings.TrimSpace(val[:end]), ",")
+	// &&NAME, or the old-style &NAME form left over after substitution
+	dd.Temp = strings.HasPrefix(dd.Dataset, "&&") ||
+		(strings.HasPrefix(dd.Dataset, "&") && !strings.Contains(dd.Dataset, "."))
+	dd.DISP, _ = ps.get("DISP")
+	dd.Space, _ = ps.get("SPACE")
+	dd.DCB, _ = ps.get("DCB")
+	parseAllocation(&dd, ps)
+	return dd
 }
 
-func parseCOND(card string) string {
-	u := strings.ToUpper(card)
-	i := strings.Index(u, "COND=")
-	if i == -1 {
-		return ""
+// location returns the source range of a statement's cards.
+func location(st statement) *ir.Location {
+	return &ir.Location{File: st.File, Line: st.Line, EndLine: st.EndLine, Col: 1, EndCol: st.EndCol}
+}
+
+// extendLocation stretches l to the end of st when both are in the same file.
+func extendLocation(l *ir.Location, st statement) {
+	if l == nil || st.File != l.File || st.EndLine < l.EndLine {
+		return
 	}
-	rest := card[i+5:]
-	// Capture until end or next blank after a keyword split; simple heuristic
-	return strings.TrimSpace(rest)
+	l.EndLine, l.EndCol = st.EndLine, st.EndCol
 }
 
-func indexAny(s, any string) int {
-	min := -1
-	for _, ch := range any {
-		if idx := strings.IndexRune(s, ch); idx != -1 {
-			if min == -1 || idx < min {
-				min = idx
-			}
-		}
+func joinData(lines []string) string {
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString(l)
+		b.WriteByte('\n')
 	}
-	return min
+	return b.String()
 }
@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+// resolveReferbacks replaces DSN=*.ddname, *.step.ddname and
+// *.step.procstep.ddname referbacks, VOL=REF= and DCB= referbacks with the
+// dataset of the referenced DD. Referbacks only look backwards: earlier DDs
+// of the same step or earlier steps, most recent first.
+func resolveReferbacks(x *expander, steps []ir.Step) {
+	for si := range steps {
+		st := &steps[si]
+		for di := range st.DD {
+			resolveDD(x, steps, si, di, &st.DD[di])
+			for ci := range st.DD[di].Concat {
+				resolveDD(x, steps, si, di, &st.DD[di].Concat[ci])
+			}
+		}
+	}
+}
+
+// resolveDD resolves the referbacks of dd, a member of DD di in step si.
+func resolveDD(x *expander, steps []ir.Step, si, di int, dd *ir.DD) {
+	resolve := func(ref string) (*ir.DD, bool) {
+		if !strings.HasPrefix(ref, "*.") {
+			return nil, false
+		}
+		target := findReferback(steps, si, di, ref)
+		if target == nil {
+			var at statement
+			if dd.Loc != nil {
+				at = statement{File: dd.Loc.File, Line: dd.Loc.Line}
+			}
+			x.warnf(at, CodeReferbackUnknown, "step %s DD %s: unresolved referback %s", steps[si].Name, dd.DDName, ref)
+		}
+		return target, true
+	}
+
+	if t, ok := resolve(dd.Dataset); ok && t != nil && t.Dataset != "" {
+		dd.Referback = dd.Dataset
+		dd.Dataset = t.Dataset
+		dd.Temp = t.Temp
+	}
+	if dd.Vol != nil {
+		if t, ok := resolve(dd.Vol.Ref); ok && t != nil && t.Dataset != "" {
+			dd.Vol.Ref = t.Dataset
+		}
+	}
+	if dd.Attrs != nil {
+		if t, ok := resolve(dd.Attrs.Model); ok && t != nil && t.Dataset != "" {
+			dd.Attrs.Model = t.Dataset
+		}
+	}
+}
+
+// findReferback locates the DD named by ref as seen from DD di of step si.
+// A two-part reference inside an expanded PROC first matches a step of the
+// same PROC invocation (*.procstep.ddname), then a job step.
+func findReferback(steps []ir.Step, si, di int, ref string) *ir.DD {
+	parts := strings.Split(strings.ToUpper(ref[2:]), ".")
+	ddname := parts[len(parts)-1]
+	byName := func(dds []ir.DD) *ir.DD {
+		for i := len(dds) - 1; i >= 0; i-- {
+			if dds[i].DDName == ddname {
+				return &dds[i]
+			}
+		}
+		return nil
+	}
+
+	var names []string
+	switch len(parts) {
+	case 1:
+		return byName(steps[si].DD[:di])
+	case 2:
+		if st := steps[si]; st.ProcStep != "" {
+			if call, _, ok := strings.Cut(st.Name, "."); ok {
+				names = append(names, qualify(call, parts[0]))
+			}
+		}
+		names = append(names, parts[0])
+	case 3:
+		names = append(names, qualify(parts[0], parts[1]))
+	default:
+		return nil
+	}
+	for _, name := range names {
+		for i := si - 1; i >= 0; i-- {
+			if steps[i].Name == name {
+				if dd := byName(steps[i].DD); dd != nil {
+					return dd
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,375 @@
+package parser
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+// JCL card layout: columns 1-71 hold the statement, column 72 is the
+// (comment) continuation indicator and columns 73-80 carry sequence numbers.
+const (
+	stmtCols     = 71
+	contCol      = 72
+	quoteContCol = 16 // continued quoted strings resume in column 16
+)
+
+// statement is one logical JCL statement assembled from one or more cards.
+type statement struct {
+	Name     string   // name field (column 3), empty for unnamed statements
+	Op       string   // operation field: JOB, EXEC, DD, PROC, PEND, SET, ... or /*JOBPARM etc. for JES2
+	Operands string   // operand field with continuations joined, comments dropped
+	Raw      string   // operands as coded when symbol substitution changed them
+	File     string   // member the cards were read from
+	Line     int      // first card (1-based)
+	EndLine  int      // last card (1-based)
+	EndCol   int      // last statement column used on the last card
+	Data     []string // in-stream records following DD * or DD DATA (delimiter excluded)
+	DataEnd  string   // what ended Data: "/*", the DLM= value, "//" or "EOF"
+
+	// Set on EXEC statements produced by PROC expansion.
+	Proc     string // PROC that supplied the step
+	ProcStep string // step name inside the PROC
+}
+
+// readStatements assembles the cards of one member, read from file, into
+// statements. Malformed cards are reported to diags and parsing resumes at
+// the next statement.
+// Comment cards (//*) are skipped. Records following DD * or DD DATA are
+// kept as in-stream data up to the delimiter (/* or DLM=); DD * data also
+// ends at the next // card. Data records that follow a step without a DD *
+// get the implicit //SYSIN DD * JES would generate.
+func readStatements(r io.Reader, file string, diags *Diagnostics) ([]statement, error) {
+	var (
+		out      []statement
+		cur      *statement
+		inQuote  bool   // current statement ends inside a quoted string
+		wantCont bool   // current statement expects an operand continuation card
+		wantThen bool   // current IF statement has not reached THEN yet
+		skipCmt  bool   // previous card flagged a comment continuation (column 72)
+		inData   bool   // collecting in-stream records for the last statement
+		dataDLM  string // delimiter ending the current in-stream data
+		dataJCL  bool   // a // card also ends the current in-stream data (DD *)
+	)
+
+	malformed := func(line int, format string, args ...any) {
+		diags.add(ir.SeverityError, CodeMalformedCard, file, line, format, args...)
+	}
+	flush := func() {
+		if cur != nil {
+			cur.File = file
+			out = append(out, *cur)
+			inData = false
+			if cur.Op == "DD" {
+				var form string
+				form, dataDLM = instream(cur.Operands)
+				inData, dataJCL = form != "", form == "*"
+			}
+			cur = nil
+		}
+		inQuote, wantCont, wantThen = false, false, false
+	}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNo := 0
+	for sc.Scan() {
+		lineNo++
+		raw := strings.TrimRight(sc.Text(), "\r\n")
+
+		if inData {
+			last := &out[len(out)-1]
+			switch {
+			case dataDLM == "/*" && isJES2(raw):
+				// A JES2 control statement ends the data and is processed.
+				inData, last.DataEnd = false, dataDLM
+			case strings.HasPrefix(raw, dataDLM):
+				inData, last.DataEnd = false, dataDLM
+				continue
+			case dataJCL && strings.HasPrefix(raw, "//"):
+				inData, last.DataEnd = false, "//"
+			default:
+				last.Data = append(last.Data, raw)
+				continue
+			}
+		}
+
+		if strings.HasPrefix(raw, "//*") {
+			// Comment cards may sit between continuation cards.
+			continue
+		}
+		if !strings.HasPrefix(raw, "//") {
+			// Delimiters, JES2 statements and stray records end any pending
+			// continuation.
+			if cur != nil {
+				flush()
+			}
+			skipCmt = false
+			if implicitData(raw, out) {
+				out = append(out, statement{
+					File:     file,
+					Name:     "SYSIN",
+					Op:       "DD",
+					Operands: "*",
+					Line:     lineNo,
+					EndLine:  lineNo,
+					Data:     []string{raw},
+				})
+				inData, dataDLM, dataJCL = true, "/*", true
+				continue
+			}
+			if isJES2(raw) {
+				card, _ := cardText(raw)
+				op, rest, _ := strings.Cut(card, " ")
+				out = append(out, statement{
+					File:     file,
+					Op:       strings.ToUpper(op),
+					Operands: strings.Join(strings.Fields(rest), " "),
+					Line:     lineNo,
+					EndLine:  lineNo,
+					EndCol:   len(card),
+				})
+			}
+			continue
+		}
+
+		card, indicator := cardText(raw)
+
+		if cur != nil && wantThen {
+			if isContinuation(card) {
+				text, done := ifText(strings.TrimLeft(card[2:], " "))
+				cur.Operands = strings.TrimSpace(cur.Operands + " " + text)
+				cur.EndLine, cur.EndCol = lineNo, len(card)
+				if done {
+					flush()
+				}
+				continue
+			}
+			malformed(cur.Line, "IF statement has no THEN")
+			flush()
+		}
+
+		if cur != nil && wantCont {
+			if isContinuation(card) {
+				text, more, q := scanOperands(continuationText(card, inQuote), inQuote)
+				cur.Operands += text
+				cur.EndLine, cur.EndCol = lineNo, len(card)
+				inQuote, wantCont = q, more
+				skipCmt = !more && indicator
+				if !wantCont {
+					flush()
+				}
+				continue
+			}
+			// Expected a continuation but got a new statement: close the
+			// current one as-is and process this card normally.
+			malformed(lineNo, "expected a continuation card for the statement on line %d", cur.Line)
+			flush()
+		}
+
+		if skipCmt && isContinuation(card) {
+			skipCmt = indicator
+			continue
+		}
+		skipCmt = false
+
+		name, op, rest := splitFields(card[2:])
+		if op == "" {
+			// Null statement (//) or a bare name: end of job / nothing to do.
+			if name != "" {
+				malformed(lineNo, "statement %s has no operation field", name)
+			}
+			continue
+		}
+		if name != "" && !validName(strings.ToUpper(name)) {
+			malformed(lineNo, "invalid name field %q", name)
+		}
+		if !knownOps[strings.ToUpper(op)] {
+			malformed(lineNo, "unknown operation %s", op)
+		}
+		if strings.EqualFold(op, "IF") {
+			// IF expressions may contain blanks; they end at THEN.
+			text, done := ifText(rest)
+			cur = &statement{Name: strings.ToUpper(name), Op: "IF", Operands: text, Line: lineNo, EndLine: lineNo, EndCol: len(card)}
+			wantThen = !done
+			if done {
+				flush()
+			}
+			continue
+		}
+		text, more, q := scanOperands(rest, false)
+		cur = &statement{
+			Name:     strings.ToUpper(name),
+			Op:       strings.ToUpper(op),
+			Operands: text,
+			Line:     lineNo,
+			EndLine:  lineNo,
+			EndCol:   len(card),
+		}
+		inQuote, wantCont = q, more
+		skipCmt = !more && indicator
+		if !wantCont {
+			flush()
+		}
+	}
+	if cur != nil {
+		switch {
+		case wantThen:
+			malformed(cur.Line, "IF statement has no THEN")
+		case wantCont:
+			malformed(cur.Line, "statement continued past the end of the member")
+		}
+		flush()
+	}
+	if inData && len(out) > 0 {
+		last := &out[len(out)-1]
+		last.DataEnd = "EOF"
+		diags.add(ir.SeverityWarning, CodeInstreamOpen, file, last.Line,
+			"in-stream data for DD %s runs to the end of the member without a delimiter", last.Name)
+	}
+	return out, sc.Err()
+}
+
+// cardText truncates a card to the statement columns and reports whether
+// column 72 carries a continuation indicator.
+func cardText(line string) (string, bool) {
+	indicator := len(line) >= contCol && line[contCol-1] != ' '
+	if len(line) > stmtCols {
+		line = line[:stmtCols]
+	}
+	return strings.TrimRight(line, " "), indicator
+}
+
+// isContinuation reports whether card is a continuation card: // followed
+// by a blank in column 3.
+func isContinuation(card string) bool {
+	return len(card) == 2 || (len(card) > 2 && card[2] == ' ')
+}
+
+// continuationText returns the operand text of a continuation card. A
+// continued quoted string resumes in column 16; ordinary operands resume at
+// the first non-blank in columns 4-16.
+func continuationText(card string, inQuote bool) string {
+	if inQuote && len(card) >= quoteContCol && strings.TrimSpace(card[2:quoteContCol-1]) == "" {
+		return card[quoteContCol-1:]
+	}
+	return strings.TrimLeft(card[2:], " ")
+}
+
+// splitFields splits the text after // into name, operation and the rest.
+func splitFields(s string) (name, op, rest string) {
+	if s != "" && s[0] != ' ' {
+		i := strings.IndexByte(s, ' ')
+		if i == -1 {
+			return s, "", ""
+		}
+		name, s = s[:i], s[i:]
+	}
+	s = strings.TrimLeft(s, " ")
+	if i := strings.IndexByte(s, ' '); i != -1 {
+		return name, s[:i], strings.TrimLeft(s[i:], " ")
+	}
+	return name, s, ""
+}
+
+// scanOperands reads the operand field up to the first blank outside quotes.
+// It reports whether the statement continues on the next card (trailing
+// comma, or a quoted string still open at the end of the card).
+func scanOperands(s string, inQuote bool) (text string, cont bool, quoteOpen bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if inQuote {
+			b.WriteByte(ch)
+			if ch == '\'' {
+				if i+1 < len(s) && s[i+1] == '\'' {
+					b.WriteByte('\'')
+					i++
+					continue
+				}
+				inQuote = false
+			}
+			continue
+		}
+		if ch == ' ' {
+			break
+		}
+		if ch == '\'' {
+			inQuote = true
+		}
+		b.WriteByte(ch)
+	}
+	text = b.String()
+	if inQuote {
+		return text, true, true
+	}
+	return text, strings.HasSuffix(text, ","), false
+}
+
+// ifText returns the IF expression on a card up to the THEN keyword and
+// whether THEN was found.
+func ifText(s string) (string, bool) {
+	u := strings.ToUpper(s)
+	for i := strings.Index(u, "THEN"); i != -1; {
+		before := i == 0 || u[i-1] == ' ' || u[i-1] == ')'
+		after := i+4 == len(u) || u[i+4] == ' '
+		if before && after {
+			return strings.TrimSpace(s[:i]), true
+		}
+		next := strings.Index(u[i+4:], "THEN")
+		if next == -1 {
+			break
+		}
+		i += 4 + next
+	}
+	return strings.TrimSpace(s), false
+}
+
+// isInstream reports whether DD operands introduce in-stream data.
+func isInstream(operands string) bool {
+	form, _ := instream(operands)
+	return form != ""
+}
+
+// instream returns the in-stream form of DD operands ("*" or "DATA", "" when
+// the DD has no in-stream data) and the delimiter that ends the data.
+func instream(operands string) (form, dlm string) {
+	ps := splitParams(operands)
+	if len(ps) == 0 || ps[0].Key != "" {
+		return "", ""
+	}
+	switch form = strings.ToUpper(ps[0].Value); form {
+	case "*", "DATA":
+	default:
+		return "", ""
+	}
+	dlm = "/*"
+	if v, ok := ps.get("DLM"); ok {
+		if v = unquote(strings.TrimSpace(v)); v != "" {
+			dlm = v
+		}
+	}
+	return form, dlm
+}
+
+// implicitData reports whether raw, a card that is not a JCL statement, is a
+// data record JES would attach to an implicit //SYSIN DD *: a non-blank
+// record directly following an EXEC or a DD without in-stream data.
+func implicitData(raw string, out []statement) bool {
+	if strings.TrimSpace(raw) == "" || strings.HasPrefix(raw, "/*") || len(out) == 0 {
+		return false
+	}
+	last := out[len(out)-1]
+	return last.Op == "EXEC" || (last.Op == "DD" && !isInstream(last.Operands))
+}
+
+// isJES2 reports whether a /* record is a JES2 control statement rather than
+// a plain delimiter.
+func isJES2(line string) bool {
+	u := strings.ToUpper(line)
+	return strings.HasPrefix(u, "/*JOBPARM") || strings.HasPrefix(u, "/*ROUTE") ||
+		strings.HasPrefix(u, "/*OUTPUT") || strings.HasPrefix(u, "/*PRIORITY") ||
+		strings.HasPrefix(u, "/*XEQ") || strings.HasPrefix(u, "/*NETACCT")
+}
@@ -0,0 +1,91 @@
+package parser
+
+import "strings"
+
+// symtab maps symbol names (upper case, without the leading &) to values.
+// Scopes are copied on write so snapshots can be shared between statements.
+type symtab map[string]string
+
+// with returns a copy of t with the keyword parameters of ps assigned.
+// Values keep their text except that enclosing apostrophes are removed.
+func (t symtab) with(ps params) symtab {
+	out := make(symtab, len(t)+len(ps))
+	for k, v := range t {
+		out[k] = v
+	}
+	for _, p := range ps {
+		if p.Key == "" {
+			continue
+		}
+		out[p.Key] = unquote(p.Value)
+	}
+	return out
+}
+
+// substitute replaces &NAME symbols in s. A period directly after a symbol
+// name is the delimiter and is consumed, so "&HLQ..DATA" yields "PROD.DATA".
+// "&&" (temporary dataset prefix) is left alone, as are undefined symbols,
+// whose names are returned.
+func substitute(s string, syms symtab) (string, []string) {
+	if strings.IndexByte(s, '&') == -1 {
+		return s, nil
+	}
+	var (
+		b         strings.Builder
+		undefined []string
+	)
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if ch != '&' {
+			b.WriteByte(ch)
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '&' {
+			b.WriteString("&&")
+			i++
+			continue
+		}
+		j := i + 1
+		if j >= len(s) || !isSymbolStart(s[j]) {
+			b.WriteByte(ch)
+			continue
+		}
+		for j < len(s) && j-i <= 8 && isSymbolChar(s[j]) {
+			j++
+		}
+		name := strings.ToUpper(s[i+1 : j])
+		val, ok := syms[name]
+		if !ok {
+			undefined = append(undefined, name)
+			b.WriteString(s[i:j])
+			i = j - 1
+			continue
+		}
+		b.WriteString(val)
+		if j < len(s) && s[j] == '.' {
+			j++
+		}
+		i = j - 1
+	}
+	return b.String(), undefined
+}
+
+func isSymbolStart(c byte) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || c == '@' || c == '#' || c == '$'
+}
+
+func isSymbolChar(c byte) bool {
+	return isSymbolStart(c) || (c >= '0' && c <= '9')
+}
+
+// tempDSN reports whether an unresolved &NAME is the whole DSN value, the
+// old-style temporary dataset form rather than a missing symbol.
+func tempDSN(operands, name string) bool {
+	ps := splitParams(operands)
+	for _, key := range []string{"DSN", "DSNAME"} {
+		if v, ok := ps.get(key); ok && strings.EqualFold(v, "&"+name) {
+			return true
+		}
+	}
+	return false
+}
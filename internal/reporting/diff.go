@@ -26,12 +26,13 @@ type diffSummary struct {
 }
 
 type diffFinding struct {
-	RuleID   string  `json:"rule_id"`
-	Job      string  `json:"job"`
-	Step     string  `json:"step,omitempty"`
-	Severity string  `json:"severity,omitempty"`
-	Message  string  `json:"message,omitempty"`
-	SavMIPS  float64 `json:"savings_mips,omitempty"`
+	Fingerprint string  `json:"fingerprint"`
+	RuleID      string  `json:"rule_id"`
+	Job         string  `json:"job"`
+	Step        string  `json:"step,omitempty"`
+	Severity    string  `json:"severity,omitempty"`
+	Message     string  `json:"message,omitempty"`
+	SavMIPS     float64 `json:"savings_mips,omitempty"`
 }
 
 type diffChanged struct {
@@ -94,8 +95,8 @@ func WriteDiffJSON(baseID, headID, outDir string, base, head *ir.Run) (string, e
 	}
 
 	// stable sort
-	sort.Slice(added, func(i, j int) bool { return added[i].RuleID < added[j].RuleID })
-	sort.Slice(removed, func(i, j int) bool { return removed[i].RuleID < removed[j].RuleID })
+	sort.Slice(added, func(i, j int) bool { return diffLess(added[i], added[j]) })
+	sort.Slice(removed, func(i, j int) bool { return diffLess(removed[i], removed[j]) })
 	sort.Slice(changed, func(i, j int) bool { return changed[i].Key < changed[j].Key })
 
 	payload := diffPayload{
@@ -116,27 +117,31 @@ func WriteDiffJSON(baseID, headID, outDir string, base, head *ir.Run) (string, e
 	return path, os.WriteFile(path, b, 0o644)
 }
 
+// keyOf is a finding's fingerprint; runs stored before fingerprints get
+// theirs computed the same way.
 func keyOf(f ir.Finding) string {
-	sb := strings.Builder{}
-	sb.WriteString(norm(f.RuleID))
-	sb.WriteByte('|')
-	sb.WriteString(norm(f.Job))
-	sb.WriteByte('|')
-	sb.WriteString(norm(f.Step))
-	sb.WriteByte('|')
-	// evidence drives logical identity for many rules
-	sb.WriteString(norm(f.Evidence))
-	return sb.String()
+	if f.Fingerprint != "" {
+		return f.Fingerprint
+	}
+	return ir.FingerprintOf(f)
+}
+
+func diffLess(a, b diffFinding) bool {
+	if a.RuleID != b.RuleID {
+		return a.RuleID < b.RuleID
+	}
+	return a.Fingerprint < b.Fingerprint
 }
 
 func asDiff(f ir.Finding) diffFinding {
 	return diffFinding{
-		RuleID:   f.RuleID,
-		Job:      f.Job,
-		Step:     f.Step,
-		Severity: f.Severity,
-		Message:  f.Message,
-		SavMIPS:  f.SavingsMIPS,
+		Fingerprint: keyOf(f),
+		RuleID:      f.RuleID,
+		Job:         f.Job,
+		Step:        f.Step,
+		Severity:    f.Severity,
+		Message:     f.Message,
+		SavMIPS:     f.SavingsMIPS,
 	}
 }
 
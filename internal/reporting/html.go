@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"time"
 
 	"github.com/codewithboateng/jclift/internal/ir"
 )
@@ -31,7 +32,7 @@ func WriteHTML(runID, outDir string, run *ir.Run) (string, error) {
 	fmt.Fprint(f, "<style>body{font-family:system-ui,Arial,sans-serif;padding:20px} table{border-collapse:collapse} td,th{border:1px solid #ddd;padding:6px} .dim{color:#666}</style>")
 	fmt.Fprint(f, "</head><body>")
 	fmt.Fprintf(f, "<h1>jclift report – %s</h1>", html.EscapeString(runID))
-	fmt.Fprintf(f, "<p>Jobs: %d &nbsp; Findings: %d</p>", len(run.Jobs), len(run.Findings))
+	fmt.Fprintf(f, "<p>Jobs: %d &nbsp; Findings: %d &nbsp; Suppressed: %d &nbsp; Parse diagnostics: %d</p>", len(run.Jobs), len(run.Findings), len(run.SuppressedFindings), len(run.Diagnostics))
 	fmt.Fprintf(f, "<p><b>Estimated totals</b>: CPU=%.1fs &nbsp; MIPS=%.1f &nbsp; USD=%.2f <span class='dim'>(heuristic)</span></p>", totalCPU, totalMIPS, totalUSD)
 	if run.Context.MIPSToUSD > 0 {
 		fmt.Fprintf(f, "<p class='dim'>Rate: 1 MIPS ≈ %.2f USD</p>", run.Context.MIPSToUSD)
@@ -66,17 +67,18 @@ func WriteHTML(runID, outDir string, run *ir.Run) (string, error) {
 		return tops[i].usd > tops[j].usd
 	})
 	if len(tops) > 0 {
-		fmt.Fprint(f, "<h2>Top Offenders</h2><table><tr><th>Rule</th><th>Job</th><th>Step</th><th>Projected USD</th><th>Projected MIPS</th><th>Message</th></tr>")
+		fmt.Fprint(f, "<h2>Top Offenders</h2><table><tr><th>Rule</th><th>Job</th><th>Step</th><th>Location</th><th>Projected USD</th><th>Projected MIPS</th><th>Message</th></tr>")
 		limit := len(tops)
 		if limit > 20 {
 			limit = 20
 		}
 		for i := 0; i < limit; i++ {
 			fd := tops[i].Finding
-			fmt.Fprintf(f, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%.2f</td><td>%.2f</td><td>%s</td></tr>",
+			fmt.Fprintf(f, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%.2f</td><td>%.2f</td><td>%s</td></tr>",
 				html.EscapeString(fd.RuleID),
 				html.EscapeString(fd.Job),
 				html.EscapeString(fd.Step),
+				html.EscapeString(fd.Loc.String()),
 				fd.SavingsUSD,
 				fd.SavingsMIPS,
 				html.EscapeString(fd.Message),
@@ -86,21 +88,100 @@ func WriteHTML(runID, outDir string, run *ir.Run) (string, error) {
 	}
 
 	if len(run.Findings) > 0 {
-		fmt.Fprint(f, "<h2>All Findings</h2><table><tr><th>Severity</th><th>Rule</th><th>Job</th><th>Step</th><th>Message</th></tr>")
+		fmt.Fprint(f, "<h2>All Findings</h2>")
+		if len(run.History) > 0 {
+			byStatus := map[string]int{}
+			for _, fd := range run.Findings {
+				if h, ok := run.History[fd.Fingerprint]; ok {
+					byStatus[h.Status()]++
+				}
+			}
+			fmt.Fprintf(f, "<p class='dim'>New: %d &nbsp; Open: %d &nbsp; Regressed: %d</p>",
+				byStatus[ir.FindingNew], byStatus[ir.FindingOpen], byStatus[ir.FindingRegressed])
+		}
+		fmt.Fprint(f, "<table><tr><th>Severity</th><th>Rule</th><th>Job</th><th>Step</th><th>Location</th>")
+		if len(run.History) > 0 {
+			fmt.Fprint(f, "<th>Status</th><th>First Seen</th><th>Age</th><th>Runs</th>")
+		}
+		fmt.Fprint(f, "<th>Message</th></tr>")
 		for _, fd := range run.Findings {
-			fmt.Fprintf(f, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>",
+			fmt.Fprintf(f, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td>",
 				html.EscapeString(fd.Severity),
 				html.EscapeString(fd.RuleID),
 				html.EscapeString(fd.Job),
 				html.EscapeString(fd.Step),
-				html.EscapeString(fd.Message),
+				html.EscapeString(fd.Loc.String()),
 			)
+			if len(run.History) > 0 {
+				if h, ok := run.History[fd.Fingerprint]; ok {
+					fmt.Fprintf(f, "<td>%s</td><td>%s</td><td>%s</td><td>%d</td>",
+						html.EscapeString(h.Status()),
+						html.EscapeString(h.FirstSeenRun),
+						formatAge(h.Age(run.StartedAt)),
+						h.Occurrences,
+					)
+				} else {
+					fmt.Fprint(f, "<td></td><td></td><td></td><td></td>")
+				}
+			}
+			fmt.Fprintf(f, "<td>%s</td></tr>", html.EscapeString(fd.Message))
 		}
 		fmt.Fprint(f, "</table>")
 	} else {
 		fmt.Fprint(f, "<h2>All Findings</h2><p class='dim'>No findings at or above the configured threshold.</p>")
 	}
 
+	if len(run.SuppressedFindings) > 0 {
+		byReason := map[string]int{}
+		for _, sf := range run.SuppressedFindings {
+			byReason[sf.Reason]++
+		}
+		fmt.Fprintf(f, "<h2>Suppressed Findings</h2><p class='dim'>Not counted above: below threshold %d, disabled rules %d, waived %d</p>",
+			byReason[ir.SuppressedThreshold], byReason[ir.SuppressedDisabled], byReason[ir.SuppressedWaived])
+		fmt.Fprint(f, "<table><tr><th>Reason</th><th>Severity</th><th>Rule</th><th>Job</th><th>Step</th><th>Location</th><th>Message</th></tr>")
+		for _, sf := range run.SuppressedFindings {
+			reason := sf.Reason
+			if sf.WaiverID != 0 {
+				reason = fmt.Sprintf("%s (waiver #%d)", reason, sf.WaiverID)
+			}
+			fmt.Fprintf(f, "<tr class='dim'><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>",
+				html.EscapeString(reason),
+				html.EscapeString(sf.Severity),
+				html.EscapeString(sf.RuleID),
+				html.EscapeString(sf.Job),
+				html.EscapeString(sf.Step),
+				html.EscapeString(sf.Loc.String()),
+				html.EscapeString(sf.Message),
+			)
+		}
+		fmt.Fprint(f, "</table>")
+	}
+
+	if len(run.Diagnostics) > 0 {
+		fmt.Fprint(f, "<h2>Parse Diagnostics</h2><table><tr><th>Severity</th><th>Code</th><th>Location</th><th>Message</th></tr>")
+		for _, d := range run.Diagnostics {
+			loc := d.File
+			if d.Line > 0 {
+				loc = fmt.Sprintf("%s:%d", d.File, d.Line)
+			}
+			fmt.Fprintf(f, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>",
+				html.EscapeString(d.Severity),
+				html.EscapeString(d.Code),
+				html.EscapeString(loc),
+				html.EscapeString(d.Message),
+			)
+		}
+		fmt.Fprint(f, "</table>")
+	}
+
 	fmt.Fprint(f, "</body></html>")
 	return path, nil
 }
+
+// formatAge renders how long a finding has been open in whole days.
+func formatAge(d time.Duration) string {
+	if d < 24*time.Hour {
+		return "&lt;1d"
+	}
+	return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+}
@@ -0,0 +1,86 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/lineage"
+)
+
+// RunLineage returns the run's lineage graph, building it when the caller
+// did not (tests, API re-evaluation).
+func RunLineage(run *ir.Run) *ir.Lineage {
+	if run.Lineage != nil {
+		return run.Lineage
+	}
+	return lineage.Build(run)
+}
+
+// DatasetGroup is the lineage edges of one dataset that a cross-job rule
+// selected, in run order.
+type DatasetGroup struct {
+	Dataset string
+	Edges   []ir.LineageEdge
+}
+
+// GroupEdges collects the edges keep selects by dataset, in order of each
+// dataset's first selected edge.
+func GroupEdges(g *ir.Lineage, keep func(e ir.LineageEdge) bool) []DatasetGroup {
+	var out []DatasetGroup
+	index := map[string]int{}
+	for _, e := range g.Edges {
+		if !keep(e) {
+			continue
+		}
+		i, ok := index[e.Dataset]
+		if !ok {
+			i = len(out)
+			index[e.Dataset] = i
+			out = append(out, DatasetGroup{Dataset: e.Dataset})
+		}
+		out[i].Edges = append(out[i].Edges, e)
+	}
+	return out
+}
+
+// EdgeJobs lists the distinct jobs of edges in order of appearance.
+func EdgeJobs(edges []ir.LineageEdge) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, e := range edges {
+		if !seen[e.Job] {
+			seen[e.Job] = true
+			out = append(out, e.Job)
+		}
+	}
+	return out
+}
+
+// CrossJobFinding reports one dataset used by several jobs, anchored at its
+// first edge, with every edge as evidence.
+func CrossJobFinding(rule Rule, grp DatasetGroup, message string) ir.Finding {
+	first := grp.Edges[0]
+	ev := make([]string, 0, len(grp.Edges))
+	for _, e := range grp.Edges {
+		use := e.Job + "." + e.Step + "." + e.DD
+		if e.Generation != "" {
+			use += " (" + e.Generation + ")"
+		}
+		if e.Disp != "" {
+			use += " DISP=" + e.Disp
+		}
+		ev = append(ev, use)
+	}
+	return ir.Finding{
+		RuleID:   rule.ID,
+		Type:     rule.Type,
+		Job:      first.Job,
+		Jobs:     EdgeJobs(grp.Edges),
+		Step:     first.Step,
+		DD:       first.DD,
+		Dataset:  grp.Dataset,
+		Loc:      first.Loc,
+		Message:  message,
+		Evidence: grp.Dataset + ": " + strings.Join(ev, ", "),
+	}
+}
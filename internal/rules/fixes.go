@@ -0,0 +1,59 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+// Fixes are only suggested for statements coded in the job itself: a step
+// supplied by a PROC lives in a member other jobs share.
+
+// deleteStepFix removes the step's EXEC statement, its DDs and their
+// in-stream data.
+func deleteStepFix(st ir.Step, desc string) *ir.Fix {
+	if st.Proc != "" || st.Loc == nil {
+		return nil
+	}
+	return &ir.Fix{Description: desc, Edits: []ir.Edit{{
+		Op: ir.EditDelete, Loc: st.Loc, Stmt: "EXEC", Name: st.Name,
+	}}}
+}
+
+// setExecFix sets a keyword parameter on the step's EXEC statement.
+func setExecFix(st ir.Step, param, value, desc string) *ir.Fix {
+	if st.Proc != "" || st.Loc == nil {
+		return nil
+	}
+	return &ir.Fix{Description: desc, Edits: []ir.Edit{{
+		Op: ir.EditSet, Loc: &ir.Location{File: st.Loc.File, Line: st.Loc.Line},
+		Stmt: "EXEC", Name: st.Name, Param: param, Value: value,
+	}}}
+}
+
+// setDDFix sets a keyword parameter on a DD statement; name is the DD's
+// name field, empty for a concatenation member.
+func setDDFix(st ir.Step, dd ir.DD, name, param, value, desc string) *ir.Fix {
+	if st.Proc != "" || dd.Loc == nil {
+		return nil
+	}
+	return &ir.Fix{Description: desc, Edits: []ir.Edit{{
+		Op: ir.EditSet, Loc: &ir.Location{File: dd.Loc.File, Line: dd.Loc.Line},
+		Stmt: "DD", Name: name, Param: param, Value: value,
+	}}}
+}
+
+// dispValue renders a disposition as a DISP= value.
+func dispValue(status, normal, abnormal string) string {
+	if status == "" {
+		status = "NEW"
+	}
+	parts := []string{status, normal, abnormal}
+	for len(parts) > 1 && parts[len(parts)-1] == "" {
+		parts = parts[:len(parts)-1]
+	}
+	if len(parts) == 1 {
+		return status
+	}
+	return "(" + strings.Join(parts, ",") + ")"
+}
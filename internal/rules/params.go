@@ -0,0 +1,187 @@
+package rules
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Parameter types.
+const (
+	ParamInt    = "int"
+	ParamFloat  = "float"
+	ParamString = "string"
+	ParamBool   = "bool"
+)
+
+// Param declares a tunable of a rule. Values come from
+// Settings.Params[RULE-ID][Name] (config rules.<RULE-ID>.params) and fall
+// back to Default.
+type Param struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"` // int|float|string|bool
+	Default     any    `json:"default"`
+	Description string `json:"description,omitempty"`
+}
+
+// coerce converts a configured value (as decoded from YAML or JSON) to the
+// parameter's type.
+func (p Param) coerce(v any) (any, error) {
+	switch p.Type {
+	case ParamInt:
+		switch x := v.(type) {
+		case int:
+			return x, nil
+		case int64:
+			return int(x), nil
+		case float64:
+			if x == math.Trunc(x) {
+				return int(x), nil
+			}
+		case string:
+			if n, err := strconv.Atoi(strings.TrimSpace(x)); err == nil {
+				return n, nil
+			}
+		}
+	case ParamFloat:
+		switch x := v.(type) {
+		case float64:
+			return x, nil
+		case int:
+			return float64(x), nil
+		case int64:
+			return float64(x), nil
+		case string:
+			if f, err := strconv.ParseFloat(strings.TrimSpace(x), 64); err == nil {
+				return f, nil
+			}
+		}
+	case ParamBool:
+		switch x := v.(type) {
+		case bool:
+			return x, nil
+		case string:
+			if b, err := strconv.ParseBool(strings.TrimSpace(x)); err == nil {
+				return b, nil
+			}
+		}
+	case ParamString:
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+		return fmt.Sprint(v), nil
+	}
+	return nil, fmt.Errorf("%s: %v is not a valid %s", p.Name, v, p.Type)
+}
+
+// paramValue returns the effective value of a rule's parameter: the
+// configured one when it converts, else the declared default.
+func paramValue(ruleID, name string) any {
+	r, ok := Get(ruleID)
+	if !ok {
+		return nil
+	}
+	for _, p := range r.Params {
+		if p.Name != name {
+			continue
+		}
+		if v, ok := rsettings.Params[strings.ToUpper(r.ID)][name]; ok {
+			if c, err := p.coerce(v); err == nil {
+				return c
+			}
+		}
+		return p.Default
+	}
+	return nil
+}
+
+func intParam(ruleID, name string) int {
+	v, _ := paramValue(ruleID, name).(int)
+	return v
+}
+
+func floatParam(ruleID, name string) float64 {
+	v, _ := paramValue(ruleID, name).(float64)
+	return v
+}
+
+func stringParam(ruleID, name string) string {
+	v, _ := paramValue(ruleID, name).(string)
+	return v
+}
+
+func boolParam(ruleID, name string) bool {
+	v, _ := paramValue(ruleID, name).(bool)
+	return v
+}
+
+// severityOverride returns the configured severity of a rule, if any.
+func severityOverride(ruleID string) string {
+	return strings.ToUpper(strings.TrimSpace(rsettings.Severity[strings.ToUpper(ruleID)]))
+}
+
+// CheckSettings reports severity overrides and parameters in s that name an
+// unregistered rule or parameter or do not fit its type. Call it after rule
+// packs are loaded.
+func CheckSettings(s Settings) error {
+	var errs []string
+	for id, sev := range s.Severity {
+		if _, ok := Get(id); !ok {
+			errs = append(errs, fmt.Sprintf("severity for unknown rule %s", id))
+		}
+		switch strings.ToUpper(strings.TrimSpace(sev)) {
+		case "LOW", "MEDIUM", "HIGH":
+		default:
+			errs = append(errs, fmt.Sprintf("%s: severity %q (LOW|MEDIUM|HIGH)", id, sev))
+		}
+	}
+	for id, params := range s.Params {
+		r, ok := Get(id)
+		if !ok {
+			errs = append(errs, fmt.Sprintf("params for unknown rule %s", id))
+			continue
+		}
+	next:
+		for name, v := range params {
+			for _, p := range r.Params {
+				if p.Name == name {
+					if _, err := p.coerce(v); err != nil {
+						errs = append(errs, r.ID+": "+err.Error())
+					}
+					continue next
+				}
+			}
+			errs = append(errs, fmt.Sprintf("%s has no parameter %q", r.ID, name))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Strings(errs)
+	return fmt.Errorf("rule settings: %s", strings.Join(errs, "; "))
+}
+
+// Effective returns the severity overrides and the parameter values of the
+// enabled rules as they apply to the next evaluation, for ir.Context.
+func Effective() (severities map[string]string, params map[string]map[string]any) {
+	for _, r := range List() {
+		if sev := severityOverride(r.ID); sev != "" {
+			if severities == nil {
+				severities = map[string]string{}
+			}
+			severities[r.ID] = sev
+		}
+		for _, p := range r.Params {
+			if params == nil {
+				params = map[string]map[string]any{}
+			}
+			if params[r.ID] == nil {
+				params[r.ID] = map[string]any{}
+			}
+			params[r.ID][p.Name] = paramValue(r.ID, p.Name)
+		}
+	}
+	return severities, params
+}
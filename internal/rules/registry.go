@@ -2,11 +2,12 @@ package rules
 
 import (
 	"fmt"
-	"hash/crc32"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/shared"
 )
 
 var (
@@ -31,11 +32,24 @@ func List() []Rule {
 	return out
 }
 
+// Evaluate runs every enabled rule over run and returns the findings at or
+// above the severity threshold. Findings below it are stored in
+// run.SuppressedFindings; so are those of disabled rules when
+// Settings.RecordDisabled runs them anyway. A rule that panics is
+// recovered and yields no findings for that job; run.Context.RuleStats
+// records the panics with each rule's invocations, findings and timings.
 func Evaluate(run *ir.Run) []ir.Finding {
 	var all []ir.Finding
-	rs := List()
+	rs := make([]Rule, 0, len(registry))
+	for _, r := range registry {
+		if rsettings.RecordDisabled || !rsettings.Disabled[strings.ToUpper(r.ID)] {
+			rs = append(rs, r)
+		}
+	}
+	sort.Slice(rs, func(i, j int) bool { return rs[i].ID < rs[j].ID })
 
 	seen := make(map[string]struct{}) // finding IDs seen in this run
+	prints := make(map[string]int)    // fingerprint -> findings sharing it
 	seq := 0
 
 	put := func(id string) bool {
@@ -46,53 +60,249 @@ func Evaluate(run *ir.Run) []ir.Finding {
 		return true
 	}
 
-	for i := range run.Jobs {
-		job := &run.Jobs[i]
-		for _, rule := range rs {
-			fs := rule.Eval(job)
-			for k := range fs {
-				// Ensure Job is set
-				if fs[k].Job == "" {
-					fs[k].Job = job.Name
+	// finish fills in what the rule left out and makes IDs unique; job is
+	// nil for a run-scoped finding naming a job not in the run.
+	finish := func(rule Rule, job *ir.Job, fs []ir.Finding) {
+		for k := range fs {
+			// Configured severity wins; rules that leave it empty get
+			// their DefaultSeverity
+			if sev := severityOverride(rule.ID); sev != "" {
+				fs[k].Severity = sev
+			} else if fs[k].Severity == "" {
+				fs[k].Severity = rule.DefaultSeverity
+			}
+			// Ensure Job is set
+			if fs[k].Job == "" && job != nil {
+				fs[k].Job = job.Name
+			}
+			// Point at the offending card when the rule did not
+			if fs[k].Loc == nil && job != nil {
+				fs[k].Loc = locate(job, fs[k].Step, fs[k].DD)
+			}
+			// The DD's dataset, part of the finding's identity
+			if fs[k].Dataset == "" && fs[k].DD != "" && job != nil {
+				fs[k].Dataset = ddDataset(job, fs[k].Step, fs[k].DD)
+			}
+			// Compute USD from MIPS if configured
+			if fs[k].SavingsUSD == 0 && fs[k].SavingsMIPS > 0 && run.Context.MIPSToUSD > 0 {
+				fs[k].SavingsUSD = fs[k].SavingsMIPS * run.Context.MIPSToUSD
+			}
+			// Stable identity across runs; repeats within the run (same job
+			// name in two members) are numbered in evaluation order
+			if fs[k].Fingerprint == "" {
+				fp := ir.FingerprintOf(fs[k])
+				if prints[fp]++; prints[fp] > 1 {
+					fp = fmt.Sprintf("%s-%d", fp, prints[fp])
 				}
-				// Compute USD from MIPS if configured
-				if fs[k].SavingsUSD == 0 && fs[k].SavingsMIPS > 0 && run.Context.MIPSToUSD > 0 {
-					fs[k].SavingsUSD = fs[k].SavingsMIPS * run.Context.MIPSToUSD
+				fs[k].Fingerprint = fp
+			}
+			// Guarantee unique ID within the run, derived from the
+			// fingerprint unless the rule chose one
+			id := fs[k].ID
+			if id == "" && len(fs[k].Fingerprint) >= 8 {
+				id = rule.ID + "-" + fs[k].Fingerprint[:8]
+			}
+			if id == "" || !put(id) {
+				// Assign a fresh, run-local unique id
+				for {
+					seq++
+					candidate := fmt.Sprintf("%s-%06d", rule.ID, seq)
+					if put(candidate) {
+						id = candidate
+						break
+					}
 				}
-				// Guarantee unique ID within the run
-				id := fs[k].ID
-				if id == "" || !put(id) {
-					// Assign a fresh, run-local unique id
-					for {
-						seq++
-						candidate := fmt.Sprintf("%s-%06d", rule.ID, seq)
-						if put(candidate) {
-							id = candidate
-							break
-						}
+			}
+			fs[k].ID = id
+		}
+		all = append(all, fs...)
+	}
+
+	var jobRules, runRules []Rule
+	for _, rule := range rs {
+		switch {
+		case rule.EvalRun != nil:
+			runRules = append(runRules, rule)
+		case rule.Eval != nil:
+			jobRules = append(jobRules, rule)
+		}
+	}
+
+	// Rules run on Settings.Workers goroutines, one job at a time; results
+	// are post-processed in job and rule order so IDs stay reproducible.
+	stats := make(map[string]*ir.RuleStat, len(jobRules)+len(runRules))
+	for _, rule := range jobRules {
+		stats[rule.ID] = &ir.RuleStat{Rule: rule.ID, Scope: ScopeJob}
+	}
+	for _, rule := range runRules {
+		stats[rule.ID] = &ir.RuleStat{Rule: rule.ID, Scope: ScopeRun}
+	}
+	record := func(rule Rule, where string, o outcome) {
+		st := stats[rule.ID]
+		st.Invocations++
+		st.Findings += len(o.fs)
+		st.Elapsed += o.took
+		st.Max = max(st.Max, o.took)
+		if o.panic != "" {
+			st.Panics++
+			st.LastPanic = where + o.panic
+		}
+	}
+
+	perJob := make([][]outcome, len(run.Jobs))
+	shared.ParallelFor(len(run.Jobs), rsettings.Workers, func(i int) {
+		perJob[i] = make([]outcome, len(jobRules))
+		for r, rule := range jobRules {
+			perJob[i][r] = invoke(func() []ir.Finding { return rule.Eval(&run.Jobs[i]) })
+		}
+	})
+	for i := range run.Jobs {
+		for r, rule := range jobRules {
+			where := "job " + run.Jobs[i].Name + ": "
+			record(rule, where, perJob[i][r])
+			fs := perJob[i][r].fs
+			if rule.FixRun != nil {
+				for k := range fs {
+					if p := fixRun(rule, run, &run.Jobs[i], &fs[k]); p != "" {
+						stats[rule.ID].Panics++
+						stats[rule.ID].LastPanic = where + "fix: " + p
 					}
-					fs[k].ID = id
 				}
 			}
-			all = append(all, fs...)
+			finish(rule, &run.Jobs[i], fs)
+		}
+	}
+
+	// Run-scoped rules see every job at once; their findings name the job
+	// (and step/DD) to locate them by.
+	perRule := make([]outcome, len(runRules))
+	shared.ParallelFor(len(runRules), rsettings.Workers, func(r int) {
+		perRule[r] = invoke(func() []ir.Finding { return runRules[r].EvalRun(run) })
+	})
+	jobs := make(map[string]*ir.Job, len(run.Jobs))
+	for i := len(run.Jobs) - 1; i >= 0; i-- {
+		jobs[run.Jobs[i].Name] = &run.Jobs[i] // first job of a name wins
+	}
+	for r, rule := range runRules {
+		record(rule, "", perRule[r])
+		fs := perRule[r].fs
+		for k := range fs {
+			finish(rule, jobs[fs[k].Job], fs[k:k+1])
 		}
 	}
 
 	// Stable order for reproducible outputs
-	sev := map[string]int{"HIGH": 3, "MEDIUM": 2, "LOW": 1}
-	sort.Slice(all, func(i, j int) bool {
-		if all[i].Severity == all[j].Severity {
-			return all[i].ID < all[j].ID
+	sortFindings(all)
+
+	var kept []ir.Finding
+	run.SuppressedFindings = nil
+	for _, f := range all {
+		switch {
+		case rsettings.Disabled[strings.ToUpper(f.RuleID)]:
+			run.SuppressedFindings = append(run.SuppressedFindings, ir.SuppressedFinding{Finding: f, Reason: ir.SuppressedDisabled})
+		case !severityOK(f.Severity):
+			run.SuppressedFindings = append(run.SuppressedFindings, ir.SuppressedFinding{Finding: f, Reason: ir.SuppressedThreshold})
+		default:
+			kept = append(kept, f)
+			continue
+		}
+		if st := stats[f.RuleID]; st != nil {
+			st.Suppressed++
+		}
+	}
+
+	run.Context.RuleStats = make([]ir.RuleStat, 0, len(stats))
+	for _, rule := range rs {
+		if st := stats[rule.ID]; st != nil {
+			run.Context.RuleStats = append(run.Context.RuleStats, *st)
+			delete(stats, rule.ID) // a re-registered ID shares one entry
+		}
+	}
+	return kept
+}
+
+// outcome is one invocation of a rule.
+type outcome struct {
+	fs    []ir.Finding
+	took  time.Duration
+	panic string // recovered panic value; empty if the rule returned
+}
+
+// invoke times one call of a rule, turning a panic into no findings so a
+// broken rule cannot take the whole run down.
+func invoke(eval func() []ir.Finding) (o outcome) {
+	start := time.Now()
+	defer func() {
+		o.took = time.Since(start)
+		if p := recover(); p != nil {
+			o.fs, o.panic = nil, fmt.Sprint(p)
+		}
+	}()
+	o.fs = eval()
+	return o
+}
+
+// fixRun calls the rule's FixRun for one finding, turning a panic into a
+// finding without a fix.
+func fixRun(rule Rule, run *ir.Run, job *ir.Job, f *ir.Finding) (panicked string) {
+	defer func() {
+		if p := recover(); p != nil {
+			f.Fix, panicked = nil, fmt.Sprint(p)
+		}
+	}()
+	rule.FixRun(run, job, f)
+	return ""
+}
+
+// sortFindings orders findings by descending severity, then ID.
+func sortFindings(fs []ir.Finding) {
+	sort.Slice(fs, func(i, j int) bool {
+		ri, rj := severityRank(fs[i].Severity), severityRank(fs[j].Severity)
+		if ri == rj {
+			return fs[i].ID < fs[j].ID
 		}
-		return sev[all[i].Severity] > sev[all[j].Severity]
+		return ri > rj
 	})
-	return all
 }
 
-func makeID(ruleID, job, step, evidence string, idx int) string {
-	data := fmt.Sprintf("%s|%s|%s|%s|%d", ruleID, job, step, evidence, idx)
-	sum := crc32.ChecksumIEEE([]byte(data))
-	return fmt.Sprintf("%s-%08x", ruleID, sum)
+// locate returns the source location of the named DD in the named step,
+// falling back to the step and then the job.
+func locate(job *ir.Job, step, dd string) *ir.Location {
+	if step == "" {
+		return job.Loc
+	}
+	for i := range job.Steps {
+		st := &job.Steps[i]
+		if st.Name != step {
+			continue
+		}
+		if dd != "" {
+			for j := range st.DD {
+				if st.DD[j].DDName == dd && st.DD[j].Loc != nil {
+					return st.DD[j].Loc
+				}
+			}
+		}
+		return st.Loc
+	}
+	return job.Loc
+}
+
+// ddDataset returns the dataset of DD dd in step of job, "" when the DD
+// names none or is not found.
+func ddDataset(job *ir.Job, step, dd string) string {
+	for i := range job.Steps {
+		st := &job.Steps[i]
+		if st.Name != step {
+			continue
+		}
+		for j := range st.DD {
+			if st.DD[j].DDName == dd {
+				return st.DD[j].Dataset
+			}
+		}
+	}
+	return ""
 }
 
 // Get returns a rule by ID if registered (used by HTML report to link docs).
@@ -0,0 +1,112 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/lineage"
+)
+
+var ddDeadOutput = Rule{
+	ID:              "DD-DEAD-OUTPUT",
+	Summary:         "Step creates a cataloged or passed dataset that no later step or job reads.",
+	Type:            "COST",
+	DefaultSeverity: "MEDIUM",
+	Docs:            "docs/rules/DD-DEAD-OUTPUT.md",
+	Params: []Param{
+		{Name: "include_passed", Type: ParamBool, Default: true, Description: "also flag DISP=(NEW,PASS) datasets no later step reads"},
+	},
+}
+
+func init() {
+	ddDeadOutput.EvalRun = evalDeadOutput
+	Register(ddDeadOutput)
+}
+
+// reader is one step reading a dataset: the positions of its job in the
+// run and of the step in the job, and the PDS member it reads, if any. Jobs
+// are told apart by position, as two members may hold jobs of the same name.
+type reader struct {
+	job    int
+	step   int
+	member string
+}
+
+// pdsMember splits LIB(MEMBER) into the library and the member; other
+// names come back whole. GDG generations are already gone from lineage
+// nodes, so what is left in parentheses is a member name.
+func pdsMember(ds string) (lib, member string) {
+	if open := strings.IndexByte(ds, '('); open > 0 && strings.HasSuffix(ds, ")") {
+		return ds[:open], ds[open+1 : len(ds)-1]
+	}
+	return ds, ""
+}
+
+func evalDeadOutput(run *ir.Run) []ir.Finding {
+	readers := map[string][]reader{}
+	for j, job := range run.Jobs {
+		for i, st := range job.Steps {
+			for _, dd := range allDDs(st) {
+				if lineage.Access(dd) != ir.AccessRead {
+					continue
+				}
+				if ds, _, ok := lineage.Node(job.Name, dd); ok {
+					lib, member := pdsMember(ds)
+					readers[lib] = append(readers[lib], reader{j, i, member})
+				}
+			}
+		}
+	}
+	// consumed reports whether a later step of job j, or any other job,
+	// reads ds. A library is read through any of its members, and a member
+	// by readers of the whole library. Temporary datasets are never read
+	// outside their job.
+	consumed := func(ds string, temp bool, j, step int) bool {
+		lib, member := pdsMember(ds)
+		for _, r := range readers[lib] {
+			if member != "" && r.member != "" && r.member != member {
+				continue
+			}
+			if r.job == j && r.step > step || r.job != j && !temp {
+				return true
+			}
+		}
+		return false
+	}
+
+	passed := boolParam(ddDeadOutput.ID, "include_passed")
+	var out []ir.Finding
+	for j, job := range run.Jobs {
+		for i, st := range job.Steps {
+			var dead []ir.DD
+			for _, dd := range allDDs(st) {
+				if dd.Status() != "NEW" || (dd.Disposition.Normal != "CATLG" && (!passed || dd.Disposition.Normal != "PASS")) {
+					continue
+				}
+				ds, _, ok := lineage.Node(job.Name, dd)
+				if ok && !consumed(ds, dd.Temp, j, i) {
+					dead = append(dead, dd)
+				}
+			}
+			if len(dead) == 0 {
+				continue
+			}
+			var ev []string
+			for _, dd := range dead {
+				ev = append(ev, dd.DDName+" DSN="+dd.Dataset+" DISP="+dd.DISP)
+			}
+			out = append(out, ir.Finding{
+				RuleID:      ddDeadOutput.ID,
+				Type:        ddDeadOutput.Type,
+				Job:         job.Name,
+				Step:        st.Name,
+				DD:          dead[0].DDName,
+				Loc:         dead[0].Loc,
+				Message:     "Step writes dataset(s) that no later step or analyzed job reads; if nothing outside this set consumes them, drop the output or the step.",
+				Evidence:    strings.Join(ev, ", "),
+				SavingsMIPS: st.Annotations.Cost.MIPS,
+			})
+		}
+	}
+	return out
+}
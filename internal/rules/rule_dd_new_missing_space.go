@@ -8,26 +8,36 @@ import (
 
 func init() {
 	Register(Rule{
-		ID:      "DD-NEW-MISSING-SPACE",
-		Summary: "NEW allocation without SPACE specified.",
-		Eval:    evalDDNewMissingSpace,
+		ID:              "DD-NEW-MISSING-SPACE",
+		Summary:         "NEW allocation without SPACE specified.",
+		Type:            "RISK",
+		DefaultSeverity: "LOW",
+		Docs:            "docs/rules/DD-NEW-MISSING-SPACE.md",
+		Params: []Param{
+			{Name: "space_template", Type: ParamString, Default: "(CYL,(1,1),RLSE)", Description: "SPACE= value the suggested fix adds"},
+		},
+		Eval: evalDDNewMissingSpace,
 	})
 }
 
 func evalDDNewMissingSpace(job *ir.Job) []ir.Finding {
 	var out []ir.Finding
+	space := stringParam("DD-NEW-MISSING-SPACE", "space_template")
 	for _, st := range job.Steps {
 		for _, dd := range st.DD {
-			disp := strings.ToUpper(dd.DISP)
-			if strings.Contains(disp, "NEW") && strings.TrimSpace(dd.Space) == "" {
+			// DATACLAS/LIKE/REFDD supply space from SMS or a model dataset.
+			if dd.Status() == "NEW" && dd.Alloc == nil && strings.TrimSpace(dd.Space) == "" &&
+				dd.DataClass == "" && dd.Like == "" && dd.RefDD == "" {
 				out = append(out, ir.Finding{
 					RuleID:   "DD-NEW-MISSING-SPACE",
 					Type:     "RISK",
-					Severity: "LOW",
 					Job:      job.Name,
 					Step:     st.Name,
+					DD:       dd.DDName,
+					Loc:      dd.Loc,
 					Message:  "DD allocates NEW dataset without SPACE=…; verify SMS defaults or specify SPACE to avoid abends/waste.",
 					Evidence: dd.DDName + " DISP=" + dd.DISP,
+					Fix:      setDDFix(st, dd, dd.DDName, "SPACE", space, "Add SPACE="+space+"; size it to the dataset."),
 				})
 			}
 		}
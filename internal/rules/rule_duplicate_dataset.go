@@ -8,9 +8,12 @@ import (
 
 func init() {
 	Register(Rule{
-		ID:      "DD-DUPLICATE-DATASET",
-		Summary: "Multiple DDs reference the same dataset within a step; consider consolidation.",
-		Eval:    evalDuplicateDataset,
+		ID:              "DD-DUPLICATE-DATASET",
+		Summary:         "Multiple DDs reference the same dataset within a step; consider consolidation.",
+		Type:            "RISK",
+		DefaultSeverity: "LOW",
+		Docs:            "docs/rules/DD-DUPLICATE-DATASET.md",
+		Eval:            evalDuplicateDataset,
 	})
 }
 
@@ -18,7 +21,7 @@ func evalDuplicateDataset(job *ir.Job) []ir.Finding {
 	var out []ir.Finding
 	for _, st := range job.Steps {
 		dsCounts := make(map[string]int)
-		for _, dd := range st.DD {
+		for _, dd := range allDDs(st) {
 			ds := strings.ToUpper(strings.TrimSpace(dd.Dataset))
 			if ds == "" {
 				continue
@@ -37,7 +40,6 @@ func evalDuplicateDataset(job *ir.Job) []ir.Finding {
 			out = append(out, ir.Finding{
 				RuleID:   "DD-DUPLICATE-DATASET",
 				Type:     "RISK",
-				Severity: "LOW",
 				Job:      job.Name,
 				Step:     st.Name,
 				Message:  "Same dataset referenced multiple times within the step; verify necessity to avoid serialization or confusion.",
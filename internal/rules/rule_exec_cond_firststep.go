@@ -1,20 +1,17 @@
 package rules
 
 import (
-	"regexp"
-	"strings"
-
 	"github.com/codewithboateng/jclift/internal/ir"
 )
 
-var reEven = regexp.MustCompile(`(?i)\bCOND\s*=\s*EVEN\b`)
-var reOnly = regexp.MustCompile(`(?i)\bCOND\s*=\s*ONLY\b`)
-
 func init() {
 	Register(Rule{
-		ID:      "EXEC-COND-FIRSTSTEP-MISUSE",
-		Summary: "COND=EVEN/ONLY on first step is likely pointless or misleading.",
-		Eval:    evalCondFirstStep,
+		ID:              "EXEC-COND-FIRSTSTEP-MISUSE",
+		Summary:         "COND=EVEN/ONLY on first step is likely pointless or misleading.",
+		Type:            "RISK",
+		DefaultSeverity: "LOW",
+		Docs:            "docs/rules/EXEC-COND-FIRSTSTEP-MISUSE.md",
+		Eval:            evalCondFirstStep,
 	})
 }
 
@@ -23,16 +20,14 @@ func evalCondFirstStep(job *ir.Job) []ir.Finding {
 		return nil
 	}
 	st := job.Steps[0]
-	u := strings.ToUpper(st.Conditions)
-	if reEven.MatchString(u) || reOnly.MatchString(u) {
+	if st.Cond != nil && (st.Cond.Even || st.Cond.Only) {
 		return []ir.Finding{{
 			RuleID:   "EXEC-COND-FIRSTSTEP-MISUSE",
 			Type:     "RISK",
-			Severity: "LOW",
 			Job:      job.Name,
 			Step:     st.Name,
 			Message:  "First step uses COND=EVEN/ONLY; there is no prior RC to branch on.",
-			Evidence: st.Conditions,
+			Evidence: "COND=" + st.Conditions,
 		}}
 	}
 	return nil
@@ -11,9 +11,12 @@ var gdgRe = regexp.MustCompile(`\(([+-]?\d+)\)\s*$`)
 
 func init() {
 	Register(Rule{
-		ID:      "GDG-ROLLOFF-RISK",
-		Summary: "Job reads prior GDG generation and writes current; verify roll-off logic.",
-		Eval:    evalGDGRollOff,
+		ID:              "GDG-ROLLOFF-RISK",
+		Summary:         "Job reads prior GDG generation and writes current; verify roll-off logic.",
+		Type:            "RISK",
+		DefaultSeverity: "MEDIUM",
+		Docs:            "docs/rules/GDG-ROLLOFF-RISK.md",
+		Eval:            evalGDGRollOff,
 	})
 }
 
@@ -23,7 +26,7 @@ func evalGDGRollOff(job *ir.Job) []ir.Finding {
 	var readEv, writeEv []string
 
 	for _, st := range job.Steps {
-		for _, dd := range st.DD {
+		for _, dd := range allDDs(st) {
 			ds := strings.ToUpper(dd.Dataset)
 			if ds == "" {
 				continue
@@ -36,9 +39,8 @@ func evalGDGRollOff(job *ir.Job) []ir.Finding {
 					readEv = append(readEv, st.Name+"."+dd.DDName+"="+dd.Dataset)
 				}
 				if gen == "0" {
-					// treat as write if DISP indicates NEW/CATLG or not explicitly SHR
-					upDisp := strings.ToUpper(dd.DISP)
-					if strings.Contains(upDisp, "NEW") || strings.Contains(upDisp, "CATLG") || upDisp == "" || strings.Contains(upDisp, "MOD") {
+					// treat as write if DISP allocates/catalogs or is omitted (defaults to NEW)
+					if dd.Disposition == nil || dd.Creates() || dd.Disposition.Normal == "CATLG" {
 						writeZero = true
 						writeEv = append(writeEv, st.Name+"."+dd.DDName+"="+dd.Dataset)
 					}
@@ -51,7 +53,6 @@ func evalGDGRollOff(job *ir.Job) []ir.Finding {
 		return []ir.Finding{{
 			RuleID:   "GDG-ROLLOFF-RISK",
 			Type:     "RISK",
-			Severity: "MEDIUM",
 			Job:      job.Name,
 			Message:  "Reads GDG(-1) and writes GDG(0) in same job; validate roll-off windows and restart behavior.",
 			Evidence: "reads: " + strings.Join(readEv, ", ") + " | writes: " + strings.Join(writeEv, ", "),
@@ -18,6 +18,9 @@ func init() {
 		Type:            "COST",
 		DefaultSeverity: "LOW",
 		Docs:            "docs/rules/IDCAMS-REPRO-IDENTITY.md",
+		Params: []Param{
+			{Name: "fallback_savings_mips", Type: ParamFloat, Default: 0.5, Description: "savings reported when the step has no cost estimate"},
+		},
 		Eval:            evalIDCAMSReproIdentity,
 	})
 }
@@ -29,23 +32,16 @@ func evalIDCAMSReproIdentity(job *ir.Job) []ir.Finding {
 		if !strings.EqualFold(st.Program, "IDCAMS") {
 			continue
 		}
-		sysin := ""
-		for _, dd := range st.DD {
-			if strings.EqualFold(dd.DDName, "SYSIN") {
-				sysin = dd.Content
-				break
-			}
-		}
+		sysin, _, _ := controlCards(st, "SYSIN")
 		u := strings.ToUpper(sysin)
 		if reRepro.MatchString(u) && reHasFiles.MatchString(u) && !reSelect.MatchString(u) {
 			savings := st.Annotations.Cost.MIPS
 			if savings <= 0 {
-				savings = 0.5 // conservative fallback if cost couldn’t be computed
+				savings = floatParam("IDCAMS-REPRO-IDENTITY", "fallback_savings_mips") // cost couldn’t be computed
 			}
 			out = append(out, ir.Finding{
 				RuleID:      "IDCAMS-REPRO-IDENTITY",
 				Type:        "COST",
-				Severity:    "LOW",
 				Job:         job.Name,
 				Step:        st.Name,
 				Message:     "IDCAMS REPRO without selection clauses; consider eliminating or consolidating redundant copies.",
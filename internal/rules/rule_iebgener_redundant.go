@@ -13,6 +13,9 @@ func init() {
 		Type:            "COST",
 		DefaultSeverity: "LOW",
 		Docs:            "docs/rules/IEBGENER-REDUNDANT-COPY.md",
+		Params: []Param{
+			{Name: "fallback_savings_mips", Type: ParamFloat, Default: 0.5, Description: "savings reported when the step has no cost estimate"},
+		},
 		Eval:            evalIEBGENERRedundant,
 	})
 }
@@ -24,14 +27,15 @@ func evalIEBGENERRedundant(job *ir.Job) []ir.Finding {
 		if !strings.EqualFold(st.Program, "IEBGENER") {
 			continue
 		}
-		var (
-			sysin          string
-			haveIn, haveOut bool
-		)
+		// A SYSIN dataset not loaded from a library mirror may hold edit statements
+		sysin, _, known := controlCards(st, "SYSIN")
+		if !known {
+			continue
+		}
+		sysin = strings.TrimSpace(sysin)
+		var haveIn, haveOut bool
 		for _, dd := range st.DD {
 			switch {
-			case strings.EqualFold(dd.DDName, "SYSIN"):
-				sysin = strings.TrimSpace(dd.Content)
 			case strings.EqualFold(dd.DDName, "SYSUT1"):
 				haveIn = true
 			case strings.EqualFold(dd.DDName, "SYSUT2"):
@@ -41,17 +45,18 @@ func evalIEBGENERRedundant(job *ir.Job) []ir.Finding {
 		if (sysin == "" || strings.EqualFold(sysin, "DUMMY")) && haveIn && haveOut {
 			savings := st.Annotations.Cost.MIPS
 			if savings <= 0 {
-				savings = 0.5 // safe fallback if cost wasn’t computed
+				savings = floatParam("IEBGENER-REDUNDANT-COPY", "fallback_savings_mips") // cost wasn’t computed
 			}
 			out = append(out, ir.Finding{
 				RuleID:      "IEBGENER-REDUNDANT-COPY",
 				Type:        "COST",
-				Severity:    "LOW",
 				Job:         job.Name,
 				Step:        st.Name,
 				Message:     "IEBGENER appears to copy the full dataset without filtering; consider inlining or eliminating redundant copies.",
 				Evidence:    "SYSIN=DUMMY or empty; SYSUT1→SYSUT2 full copy.",
 				SavingsMIPS: savings, // USD auto-filled by rules.Evaluate using MIPS→USD
+				Fix:         setExecFix(st, "PGM", "ICEGENER",
+					"Run the copy with ICEGENER, which hands it to DFSORT COPY, or drop the step and read SYSUT1 directly."),
 			})
 		}
 	}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/lineage"
 )
 
 func init() {
@@ -13,7 +14,11 @@ func init() {
 		Type:            "COST",
 		DefaultSeverity: "MEDIUM",
 		Docs:            "docs/rules/SORT-IDENTITY.md",
+		Params: []Param{
+			{Name: "fallback_savings_mips", Type: ParamFloat, Default: 0.8, Description: "savings reported when the step has no cost estimate"},
+		},
 		Eval:            evalSortIdentity,
+		FixRun:          fixSortIdentity,
 	})
 }
 
@@ -24,13 +29,11 @@ func evalSortIdentity(job *ir.Job) []ir.Finding {
 		if !strings.EqualFold(st.Program, "SORT") {
 			continue
 		}
-		// Pull SYSIN control cards (if any)
-		sysin := ""
-		for _, dd := range st.DD {
-			if strings.EqualFold(dd.DDName, "SYSIN") {
-				sysin = dd.Content
-				break
-			}
+		// Pull SYSIN control cards (if any); skip SYSIN datasets that
+		// were not loaded from a library mirror
+		sysin, _, known := controlCards(st, "SYSIN")
+		if !known {
+			continue
 		}
 		up := strings.ToUpper(sysin)
 		identity := strings.Contains(up, "FIELDS=COPY") || (strings.TrimSpace(sysin) == "" && len(st.DD) > 0)
@@ -41,7 +44,7 @@ func evalSortIdentity(job *ir.Job) []ir.Finding {
 		// Savings = step cost (MIPS) if available; otherwise a tiny fallback
 		savings := st.Annotations.Cost.MIPS
 		if savings <= 0 {
-			savings = 0.8
+			savings = floatParam("SORT-IDENTITY", "fallback_savings_mips")
 		}
 
 		ev := strings.TrimSpace(sysin)
@@ -52,9 +55,9 @@ func evalSortIdentity(job *ir.Job) []ir.Finding {
 		out = append(out, ir.Finding{
 			RuleID:      "SORT-IDENTITY",
 			Type:        "COST",
-			Severity:    "MEDIUM",
 			Job:         job.Name,
 			Step:        st.Name,
+			Loc:         st.Loc,
 			Message:     "SORT appears to perform an identity copy (no effective key). Consider removing or merging upstream.",
 			Evidence:    snippet(ev),
 			SavingsMIPS: savings, // USD filled by rules.Evaluate using MIPS→USD
@@ -63,6 +66,107 @@ func evalSortIdentity(job *ir.Job) []ir.Finding {
 	return out
 }
 
+// fixSortIdentity suggests removing the step only for a plain copy nothing
+// depends on, in its job or, through its SORTOUT, anywhere in the run.
+func fixSortIdentity(run *ir.Run, job *ir.Job, f *ir.Finding) {
+	for i, st := range job.Steps {
+		if st.Name != f.Step {
+			continue
+		}
+		sysin, _, _ := controlCards(st, "SYSIN")
+		if copyOnly(st, sysin) && !stepReferenced(RunLineage(run), *job, i) {
+			f.Fix = deleteStepFix(st, "Remove step "+st.Name+"; no analyzed step or job reads its SORTOUT or tests its completion.")
+		}
+		return
+	}
+}
+
+// copyOnly reports whether the step's only control statements are SORT
+// FIELDS=COPY or OPTION COPY: no INCLUDE/OMIT/INREC/OUTREC/OUTFIL, no
+// DFSPARM and no PARM that could reshape the records.
+func copyOnly(st ir.Step, sysin string) bool {
+	if st.Parm != "" || st.ParmDD != "" {
+		return false
+	}
+	for _, dd := range st.DD {
+		if strings.EqualFold(dd.DDName, "DFSPARM") || strings.EqualFold(dd.DDName, "$ORTPARM") {
+			return false
+		}
+	}
+	copies := false
+	for _, line := range strings.Split(strings.ToUpper(sysin), "\n") {
+		if strings.HasPrefix(line, "*") { // comment statement
+			continue
+		}
+		switch strings.Join(strings.Fields(line), " ") {
+		case "":
+		case "SORT FIELDS=COPY", "OPTION COPY":
+			copies = true
+		default:
+			return false
+		}
+	}
+	return copies
+}
+
+// stepReferenced reports whether anything depends on step i of job: a
+// reader of its SORTOUT dataset anywhere in the run, or a COND/IF test or
+// referback naming the step.
+func stepReferenced(g *ir.Lineage, job ir.Job, i int) bool {
+	st := job.Steps[i]
+	outputs := map[string]bool{}
+	for _, dd := range allDDs(st) {
+		if strings.EqualFold(dd.DDName, "SORTOUT") {
+			if ds, _, ok := lineage.Node(job.Name, dd); ok {
+				outputs[ds] = true
+			}
+		}
+	}
+	for _, e := range g.Edges {
+		if e.Access == ir.AccessRead && outputs[e.Dataset] {
+			return true
+		}
+	}
+
+	ref := "*." + strings.ToUpper(st.Name) + "."
+	for k, other := range job.Steps {
+		if k == i {
+			continue
+		}
+		if other.Cond != nil {
+			for _, c := range other.Cond.Tests {
+				if strings.EqualFold(c.Step, st.Name) {
+					return true
+				}
+			}
+		}
+		for _, c := range other.When {
+			if c.Expr == nil || namesStep(c.Expr, st.Name) {
+				return true // an unparsed IF may name it too
+			}
+		}
+		for _, dd := range allDDs(other) {
+			for _, s := range []string{dd.Referback, dd.RefDD, dd.DCB, dd.Like} {
+				if strings.Contains(strings.ToUpper(s), ref) {
+					return true
+				}
+			}
+			if dd.Vol != nil && strings.Contains(strings.ToUpper(dd.Vol.Ref), ref) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// namesStep reports whether an IF expression tests step.
+func namesStep(e *ir.CondExpr, step string) bool {
+	if e == nil {
+		return false
+	}
+	return strings.EqualFold(e.Step, step) || namesStep(e.Left, step) || namesStep(e.Right, step)
+}
+
 func snippet(s string) string {
 	s = strings.TrimSpace(s)
 	if len(s) > 200 {
@@ -8,9 +8,12 @@ import (
 
 func init() {
 	Register(Rule{
-		ID:      "SORT-MISSING-SYSIN",
-		Summary: "SORT step missing SYSIN; intent unclear.",
-		Eval:    evalSortMissingSYSIN,
+		ID:              "SORT-MISSING-SYSIN",
+		Summary:         "SORT step missing SYSIN; intent unclear.",
+		Type:            "RISK",
+		DefaultSeverity: "LOW",
+		Docs:            "docs/rules/SORT-MISSING-SYSIN.md",
+		Eval:            evalSortMissingSYSIN,
 	})
 }
 
@@ -29,12 +32,11 @@ func evalSortMissingSYSIN(job *ir.Job) []ir.Finding {
 		}
 		if !found {
 			out = append(out, ir.Finding{
-				RuleID:   "SORT-MISSING-SYSIN",
-				Type:     "RISK",
-				Severity: "LOW",
-				Job:      job.Name,
-				Step:     st.Name,
-				Message:  "SORT has no SYSIN; verify default behavior vs. intended transform.",
+				RuleID:  "SORT-MISSING-SYSIN",
+				Type:    "RISK",
+				Job:     job.Name,
+				Step:    st.Name,
+				Message: "SORT has no SYSIN; verify default behavior vs. intended transform.",
 			})
 		}
 	}
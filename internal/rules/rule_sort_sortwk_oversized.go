@@ -1,24 +1,24 @@
 package rules
 
 import (
-	"regexp"
-	"strconv"
 	"strings"
 
 	"github.com/codewithboateng/jclift/internal/ir"
 )
 
-// Match CYL(900,...) or CYL,(900,...) and TRK variants.
-// m[1] = unit ("CYL" or "TRK"), m[2] = primary number ("900")
-var cylRe = regexp.MustCompile(`\b(CYL|TRK)\s*,?\s*\(\s*(\d+)`)
+const sortwkRuleID = "SORT-SORTWK-OVERSIZED"
 
 func init() {
 	Register(Rule{
-		ID:              "SORT-SORTWK-OVERSIZED",
+		ID:              sortwkRuleID,
 		Summary:         "SORTWK work space appears oversized; consider tuning.",
 		Type:            "COST",
 		DefaultSeverity: "LOW",
 		Docs:            "docs/rules/SORT-SORTWK-OVERSIZED.md",
+		Params: []Param{
+			{Name: "primary_cyl_threshold", Type: ParamInt, Default: 500, Description: "SORTWK primary allocations above this many cylinders are flagged"},
+			{Name: "savings_mips_per_dd", Type: ParamFloat, Default: 0.8, Description: "estimated MIPS saved per oversized SORTWK DD"},
+		},
 		Eval:            evalSortwkOversized,
 	})
 }
@@ -26,6 +26,8 @@ func init() {
 
 func evalSortwkOversized(job *ir.Job) []ir.Finding {
 	var out []ir.Finding
+	threshold := float64(intParam(sortwkRuleID, "primary_cyl_threshold"))
+	perDD := floatParam(sortwkRuleID, "savings_mips_per_dd")
 	for _, st := range job.Steps {
 		if !strings.EqualFold(st.Program, "SORT") {
 			continue
@@ -34,15 +36,10 @@ func evalSortwkOversized(job *ir.Job) []ir.Finding {
 		var evParts []string
 		for _, dd := range st.DD {
 			dn := strings.ToUpper(dd.DDName)
-			if strings.HasPrefix(dn, "SORTWK") {
-				s := strings.ToUpper(dd.Space)
-				if m := cylRe.FindStringSubmatch(s); len(m) >= 3 {
-					// m[1] is unit, m[2] is the primary number
-					primary, _ := strconv.Atoi(m[2])
-					if primary > rsettings.SortwkPrimaryCylThreshold {
-						overs++
-						evParts = append(evParts, dn+" SPACE="+dd.Space)
-					}
+			if strings.HasPrefix(dn, "SORTWK") && dd.Alloc != nil {
+				if primaryCyl(*dd.Alloc) > threshold {
+					overs++
+					evParts = append(evParts, dn+" SPACE="+dd.Space)
 				}
 			}
 		}
@@ -50,14 +47,24 @@ func evalSortwkOversized(job *ir.Job) []ir.Finding {
 			out = append(out, ir.Finding{
 				RuleID:      "SORT-SORTWK-OVERSIZED",
 				Type:        "COST",
-				Severity:    "LOW",
 				Job:         job.Name,
 				Step:        st.Name,
 				Message:     "SORTWK primary cylinders exceed recommended thresholds; potential I/O/CPU waste.",
 				Evidence:    strings.Join(evParts, " | "),
-				SavingsMIPS: 0.8 * float64(overs), // placeholder heuristic
+				SavingsMIPS: perDD * float64(overs), // placeholder heuristic
 			})
 		}
 	}
 	return out
 }
+
+// primaryCyl expresses the primary quantity in cylinders using default
+// 3390 geometry, so TRK and block allocations compare against the same
+// threshold as CYL.
+func primaryCyl(s ir.SpaceAlloc) float64 {
+	if s.Unit == "CYL" {
+		return float64(s.Primary)
+	}
+	cyl := ir.SpaceAlloc{Unit: "CYL"}.Bytes(1, ir.Geometry{})
+	return s.Bytes(s.Primary, ir.Geometry{}) / cyl
+}
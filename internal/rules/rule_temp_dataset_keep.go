@@ -8,32 +8,60 @@ import (
 
 func init() {
 	Register(Rule{
-		ID:      "DD-TEMP-DATASET-KEEP",
-		Summary: "Temporary dataset (&&) is kept/cataloged; potential leakage.",
-		Eval:    evalTempKeep,
+		ID:              "DD-TEMP-DATASET-KEEP",
+		Summary:         "Temporary dataset (&&) is kept/cataloged; potential leakage.",
+		Type:            "RISK",
+		DefaultSeverity: "LOW",
+		Docs:            "docs/rules/DD-TEMP-DATASET-KEEP.md",
+		Eval:            evalTempKeep,
 	})
 }
 
 func evalTempKeep(job *ir.Job) []ir.Finding {
 	var out []ir.Finding
-	for _, st := range job.Steps {
-		for _, dd := range st.DD {
-			ds := strings.TrimSpace(dd.Dataset)
-			if strings.HasPrefix(ds, "&&") {
-				up := strings.ToUpper(dd.DISP)
-				if strings.Contains(up, "KEEP") || strings.Contains(up, "CATLG") {
-					out = append(out, ir.Finding{
-						RuleID:   "DD-TEMP-DATASET-KEEP",
-						Type:     "RISK",
-						Severity: "LOW",
-						Job:      job.Name,
-						Step:     st.Name,
-						Message:  "Temporary dataset (&&name) marked KEEP/CATLG; verify lifecycle to avoid catalog clutter/leaks.",
-						Evidence: dd.DDName + " " + dd.DISP,
-					})
+	for i, st := range job.Steps {
+		for _, top := range st.DD {
+			for k, dd := range top.Members() {
+				ds := strings.TrimSpace(dd.Dataset)
+				if !strings.HasPrefix(ds, "&&") || !dd.Keeps() {
+					continue
 				}
+				name := dd.DDName
+				if k > 0 {
+					name = "" // concatenation members are unnamed
+				}
+				out = append(out, ir.Finding{
+					RuleID:   "DD-TEMP-DATASET-KEEP",
+					Type:     "RISK",
+					Job:      job.Name,
+					Step:     st.Name,
+					DD:       dd.DDName,
+					Loc:      dd.Loc,
+					Message:  "Temporary dataset (&&name) marked KEEP/CATLG; verify lifecycle to avoid catalog clutter/leaks.",
+					Evidence: dd.DDName + " " + dd.DISP,
+					Fix:      tempDispFix(job, i, st, dd, name),
+				})
 			}
 		}
 	}
 	return out
 }
+
+// tempDispFix passes the temporary dataset on when a later step of the
+// job reads it and deletes it otherwise.
+func tempDispFix(job *ir.Job, i int, st ir.Step, dd ir.DD, name string) *ir.Fix {
+	normal := "DELETE"
+	for _, later := range job.Steps[i+1:] {
+		for _, r := range allDDs(later) {
+			if strings.EqualFold(strings.TrimSpace(r.Dataset), strings.TrimSpace(dd.Dataset)) {
+				normal = "PASS"
+			}
+		}
+	}
+	abnormal := dd.Disposition.Abnormal
+	if abnormal == "KEEP" || abnormal == "CATLG" {
+		abnormal = "DELETE"
+	}
+	disp := dispValue(dd.Status(), normal, abnormal)
+	return setDDFix(st, dd, name, "DISP", disp, "Code DISP="+disp+" so the temporary dataset is not kept.")
+}
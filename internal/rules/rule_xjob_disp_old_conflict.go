@@ -0,0 +1,39 @@
+package rules
+
+import (
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+var xjobDispOldConflict = Rule{
+	ID:              "XJOB-DISP-OLD-CONFLICT",
+	Summary:         "Several jobs update the same dataset with DISP=OLD or MOD.",
+	Type:            "RISK",
+	DefaultSeverity: "MEDIUM",
+	Docs:            "docs/rules/XJOB-DISP-OLD-CONFLICT.md",
+	Params: []Param{
+		{Name: "min_jobs", Type: ParamInt, Default: 2, Description: "jobs that must update the dataset to flag it"},
+	},
+}
+
+func init() {
+	xjobDispOldConflict.EvalRun = evalXJobDispOldConflict
+	Register(xjobDispOldConflict)
+}
+
+// DISP=OLD or MOD takes the exclusive enqueue whatever the DD is called,
+// so edges are picked by their disposition, not by lineage access.
+func evalXJobDispOldConflict(run *ir.Run) []ir.Finding {
+	groups := GroupEdges(RunLineage(run), func(e ir.LineageEdge) bool {
+		st := e.Disposition.Status
+		return st == "OLD" || st == "MOD"
+	})
+	var out []ir.Finding
+	for _, grp := range groups {
+		if len(EdgeJobs(grp.Edges)) < intParam(xjobDispOldConflict.ID, "min_jobs") {
+			continue
+		}
+		out = append(out, CrossJobFinding(xjobDispOldConflict, grp,
+			"Dataset is updated in place by more than one job; the jobs serialize on the exclusive enqueue and their order decides the final contents. Make the schedule dependency explicit or split the dataset."))
+	}
+	return out
+}
@@ -0,0 +1,39 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+var xjobDuplicateCreate = Rule{
+	ID:              "XJOB-DUPLICATE-CREATE",
+	Summary:         "Same cataloged dataset is created (NEW,CATLG) by more than one job.",
+	Type:            "RISK",
+	DefaultSeverity: "MEDIUM",
+	Docs:            "docs/rules/XJOB-DUPLICATE-CREATE.md",
+	Params: []Param{
+		{Name: "min_jobs", Type: ParamInt, Default: 2, Description: "jobs that must catalog the dataset to flag it"},
+	},
+}
+
+func init() {
+	xjobDuplicateCreate.EvalRun = evalXJobDuplicateCreate
+	Register(xjobDuplicateCreate)
+}
+
+func evalXJobDuplicateCreate(run *ir.Run) []ir.Finding {
+	groups := GroupEdges(RunLineage(run), func(e ir.LineageEdge) bool {
+		return e.Access == ir.AccessCreate && e.Generation == "" &&
+			!strings.Contains(e.Dataset, "&") && e.Disposition.Normal == "CATLG"
+	})
+	var out []ir.Finding
+	for _, grp := range groups {
+		if len(EdgeJobs(grp.Edges)) < intParam(xjobDuplicateCreate.ID, "min_jobs") {
+			continue
+		}
+		out = append(out, CrossJobFinding(xjobDuplicateCreate, grp,
+			"More than one job catalogs a new dataset under this name; whichever runs second fails allocation (duplicate name on volume or NOT CATLGD) unless something deletes it in between."))
+	}
+	return out
+}
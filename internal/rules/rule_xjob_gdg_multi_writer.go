@@ -0,0 +1,36 @@
+package rules
+
+import (
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+var xjobGDGMultiWriter = Rule{
+	ID:              "XJOB-GDG-MULTI-WRITER",
+	Summary:         "GDG(+1) is created by more than one job.",
+	Type:            "RISK",
+	DefaultSeverity: "MEDIUM",
+	Docs:            "docs/rules/XJOB-GDG-MULTI-WRITER.md",
+	Params: []Param{
+		{Name: "min_jobs", Type: ParamInt, Default: 2, Description: "jobs that must create GDG(+1) to flag it"},
+	},
+}
+
+func init() {
+	xjobGDGMultiWriter.EvalRun = evalXJobGDGMultiWriter
+	Register(xjobGDGMultiWriter)
+}
+
+func evalXJobGDGMultiWriter(run *ir.Run) []ir.Finding {
+	groups := GroupEdges(RunLineage(run), func(e ir.LineageEdge) bool {
+		return e.Generation == "+1" && e.Produces()
+	})
+	var out []ir.Finding
+	for _, grp := range groups {
+		if len(EdgeJobs(grp.Edges)) < intParam(xjobGDGMultiWriter.ID, "min_jobs") {
+			continue
+		}
+		out = append(out, CrossJobFinding(xjobGDGMultiWriter, grp,
+			"Several jobs create the next generation of this GDG; which one readers of (0) see depends on run order, and reruns shift the generations. Give each job its own GDG or serialize them in the schedule."))
+	}
+	return out
+}
@@ -0,0 +1,369 @@
+// Package rulestest runs rules over JCL fixtures annotated with the
+// findings they should produce, for `jclift rules test` and, through
+// rulestesting, Go rule tests.
+//
+// A fixture is a JCL member whose comment cards carry the expectations:
+//
+//	//* RULES: SORT-IDENTITY, DSL-MY-RULE
+//	//* EXPECT: SORT-IDENTITY@S1
+//	//* EXPECT: DD-TEMP-DATASET-KEEP@S2.SYSUT2
+//
+// EXPECT lists findings as RULE, RULE@STEP or RULE@STEP.DD; repeat an
+// entry to expect the finding twice. A step from a PROC is JSTEP.PSTEP, so
+// RULE@JSTEP.PSTEP names that step and RULE@JSTEP.PSTEP.DD one of its DDs. RULES names the rules under test when
+// some of them should not fire at all; by default they are the rules named
+// in EXPECT. Every finding of a rule under test must be expected and every
+// expectation met; findings of other rules are ignored.
+//
+// FIX lists, in the same form, the findings that must carry a suggested
+// fix. A fixture with FIX cards fails when any other finding of a rule
+// under test carries one.
+package rulestest
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/codewithboateng/jclift/internal/cost"
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/lineage"
+	"github.com/codewithboateng/jclift/internal/parser"
+	"github.com/codewithboateng/jclift/internal/rules"
+	"github.com/codewithboateng/jclift/internal/shared"
+)
+
+// FixtureExt is the suffix of fixture members RunDir picks up.
+const FixtureExt = ".jcl"
+
+// Mismatch kinds.
+const (
+	Missing     = "missing"      // expected finding not produced
+	Unexpected  = "unexpected"   // finding of a rule under test not expected
+	UnknownRule = "unknown-rule" // annotation names a rule that is not registered
+	ParseError  = "parse-error"  // the fixture does not parse cleanly
+	Panicked    = "panic"        // a rule under test panicked
+
+	MissingFix    = "missing-fix"    // FIX finding produced without a fix
+	UnexpectedFix = "unexpected-fix" // fix on a finding not listed in FIX
+)
+
+// Expectation is one EXPECT entry: a finding of Rule, at Step and DD when
+// given.
+type Expectation struct {
+	Rule string `json:"rule"`
+	Step string `json:"step,omitempty"`
+	DD   string `json:"dd,omitempty"`
+	Line int    `json:"line"` // annotation card
+}
+
+func (e Expectation) String() string {
+	s := e.Rule
+	if e.Step != "" {
+		s += "@" + e.Step
+		if e.DD != "" {
+			s += "." + e.DD
+		}
+	}
+	return s
+}
+
+// Fixture is a JCL member and its annotations.
+type Fixture struct {
+	File   string        `json:"file"`
+	Rules  []string      `json:"rules"` // rules under test
+	Expect []Expectation `json:"expect,omitempty"`
+	Fix    []Expectation `json:"fix,omitempty"` // findings that carry a fix
+	// CheckFixes is set by FIX cards: only the Fix findings may carry one.
+	CheckFixes bool `json:"check_fixes,omitempty"`
+
+	lines map[string]int // first annotation naming each rule
+}
+
+// Mismatch is a difference between the findings and the annotations.
+type Mismatch struct {
+	Kind    string `json:"kind"` // missing|unexpected|unknown-rule|parse-error|panic|missing-fix|unexpected-fix
+	File    string `json:"file"`
+	Line    int    `json:"line,omitempty"`
+	Rule    string `json:"rule,omitempty"`
+	Step    string `json:"step,omitempty"`
+	DD      string `json:"dd,omitempty"`
+	Message string `json:"message"`
+}
+
+func (m Mismatch) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s", m.File, m.Line, m.Kind, m.Message)
+}
+
+// Result is the outcome of one fixture.
+type Result struct {
+	Fixture    Fixture      `json:"fixture"`
+	Findings   []ir.Finding `json:"findings,omitempty"` // of the rules under test
+	Mismatches []Mismatch   `json:"mismatches,omitempty"`
+}
+
+// OK reports whether the findings matched the annotations.
+func (r Result) OK() bool { return len(r.Mismatches) == 0 }
+
+// Options controls how fixtures are parsed and costed.
+type Options struct {
+	Parser  parser.Options
+	Context *ir.Context // cost model and geometry; DefaultContext() when nil
+}
+
+// DefaultContext is the cost context of the default configuration.
+func DefaultContext() ir.Context { return ContextFrom(shared.DefaultConfig()) }
+
+// ContextFrom is the cost context analyze derives from cfg.
+func ContextFrom(cfg shared.Config) ir.Context {
+	var ctx ir.Context
+	ctx.MIPSToUSD = cfg.Analysis.MIPSToUSD
+	ctx.Geometry.TracksPerCyl = cfg.Cost.Geometry.TracksPerCyl
+	ctx.Geometry.BytesPerTrack = cfg.Cost.Geometry.BytesPerTrack
+	ctx.Model.MIPSPerCPU = cfg.Cost.Model.MIPSPerCPU
+	ctx.Model.SortAlpha = cfg.Cost.Model.Sort.Alpha
+	ctx.Model.SortBeta = cfg.Cost.Model.Sort.Beta
+	ctx.Model.CopyAlpha = cfg.Cost.Model.Copy.Alpha
+	ctx.Model.CopyBeta = cfg.Cost.Model.Copy.Beta
+	ctx.Model.IDAlpha = cfg.Cost.Model.IDCAMS.Alpha
+	ctx.Model.IDBeta = cfg.Cost.Model.IDCAMS.Beta
+	return ctx
+}
+
+// Load reads the annotations of a fixture.
+func Load(file string) (Fixture, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return Fixture{}, err
+	}
+	defer f.Close()
+
+	fx := Fixture{File: file, lines: map[string]int{}}
+	name := func(id string, line int) {
+		if _, ok := fx.lines[id]; !ok {
+			fx.lines[id] = line
+		}
+	}
+	declared := false
+	sc := bufio.NewScanner(f)
+	for line := 1; sc.Scan(); line++ {
+		text, ok := strings.CutPrefix(sc.Text(), "//*")
+		if !ok {
+			continue
+		}
+		key, rest, ok := strings.Cut(strings.TrimSpace(text), ":")
+		if !ok {
+			continue
+		}
+		switch strings.ToUpper(strings.TrimSpace(key)) {
+		case "EXPECT", "FIX":
+			fix := strings.EqualFold(strings.TrimSpace(key), "FIX")
+			fx.CheckFixes = fx.CheckFixes || fix
+			for _, tok := range fields(rest) {
+				e := Expectation{Line: line}
+				e.Rule, e.Step, _ = strings.Cut(tok, "@")
+				// The DD is the last qualifier; matches also tries the
+				// whole target as a PROC step name
+				if i := strings.LastIndexByte(e.Step, '.'); i >= 0 {
+					e.Step, e.DD = e.Step[:i], e.Step[i+1:]
+				}
+				if fix {
+					fx.Fix = append(fx.Fix, e)
+				} else {
+					fx.Expect = append(fx.Expect, e)
+				}
+				name(e.Rule, line)
+			}
+		case "RULES":
+			declared = true
+			for _, id := range fields(rest) {
+				fx.Rules = append(fx.Rules, id)
+				name(id, line)
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return Fixture{}, err
+	}
+	if !declared {
+		for _, e := range fx.Expect {
+			fx.Rules = append(fx.Rules, e.Rule)
+		}
+	}
+	fx.Rules = dedupe(fx.Rules)
+	return fx, nil
+}
+
+// fields splits an annotation list on commas and blanks, upper-cased.
+func fields(s string) []string {
+	return strings.FieldsFunc(strings.ToUpper(s), func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+}
+
+func dedupe(ids []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Run parses a fixture, costs it, builds its lineage and evaluates the
+// registered rules with the current settings. Findings the settings would
+// suppress (below the threshold, disabled rule) still count.
+func Run(fx Fixture, opts Options) Result {
+	res := Result{Fixture: fx}
+	mismatch := func(m Mismatch) {
+		m.File = fx.File
+		res.Mismatches = append(res.Mismatches, m)
+	}
+
+	underTest := map[string]bool{}
+	for _, id := range fx.Rules {
+		underTest[id] = true
+	}
+	named := make([]string, 0, len(fx.lines))
+	for id := range fx.lines {
+		named = append(named, id)
+	}
+	sort.Strings(named)
+	for _, id := range named {
+		if _, ok := rules.Get(id); !ok {
+			mismatch(Mismatch{Kind: UnknownRule, Line: fx.lines[id], Rule: id, Message: "rule " + id + " is not registered"})
+		}
+	}
+
+	run, diags := parser.ParseSources([]string{fx.File}, opts.Parser)
+	for _, d := range diags.Items {
+		if d.Severity == ir.SeverityError {
+			mismatch(Mismatch{Kind: ParseError, Line: d.Line, Message: d.Code + ": " + d.Message})
+		}
+	}
+	run.Context = DefaultContext()
+	if opts.Context != nil {
+		run.Context = *opts.Context
+	}
+	run.Lineage = lineage.Build(&run)
+	cost.Annotate(&run, opts.Parser.Workers)
+
+	all := rules.Evaluate(&run)
+	for _, st := range run.Context.RuleStats {
+		if id := strings.ToUpper(st.Rule); underTest[id] && st.Panics > 0 {
+			mismatch(Mismatch{Kind: Panicked, Line: fx.lines[id], Rule: st.Rule,
+				Message: fmt.Sprintf("%s panicked %d times: %s", st.Rule, st.Panics, st.LastPanic)})
+		}
+	}
+	for _, sf := range run.SuppressedFindings {
+		all = append(all, sf.Finding)
+	}
+	for _, f := range all {
+		if underTest[strings.ToUpper(f.RuleID)] {
+			res.Findings = append(res.Findings, f)
+		}
+	}
+
+	used := make([]bool, len(res.Findings))
+	for _, e := range fx.Expect {
+		found := false
+		for i, f := range res.Findings {
+			if !used[i] && matches(e, f) {
+				used[i], found = true, true
+				break
+			}
+		}
+		if !found {
+			mismatch(Mismatch{Kind: Missing, Line: e.Line, Rule: e.Rule, Step: e.Step, DD: e.DD,
+				Message: "expected " + e.String()})
+		}
+	}
+	if fx.CheckFixes {
+		fixed := make([]bool, len(res.Findings))
+		for _, e := range fx.Fix {
+			found := false
+			for i, f := range res.Findings {
+				if !fixed[i] && f.Fix != nil && matches(e, f) {
+					fixed[i], found = true, true
+					break
+				}
+			}
+			if !found {
+				mismatch(Mismatch{Kind: MissingFix, Line: e.Line, Rule: e.Rule, Step: e.Step, DD: e.DD,
+					Message: "expected a fix on " + e.String()})
+			}
+		}
+		for i, f := range res.Findings {
+			if f.Fix == nil || fixed[i] {
+				continue
+			}
+			m := Mismatch{Kind: UnexpectedFix, Rule: f.RuleID, Step: f.Step, DD: f.DD,
+				Message: "unexpected fix on " + Expectation{Rule: f.RuleID, Step: f.Step, DD: f.DD}.String() + ": " + f.Fix.Description}
+			if f.Loc != nil {
+				m.Line = f.Loc.Line
+			}
+			mismatch(m)
+		}
+	}
+	for i, f := range res.Findings {
+		if used[i] {
+			continue
+		}
+		m := Mismatch{Kind: Unexpected, Rule: f.RuleID, Step: f.Step, DD: f.DD,
+			Message: "unexpected " + Expectation{Rule: f.RuleID, Step: f.Step, DD: f.DD}.String() + ": " + f.Message}
+		if f.Loc != nil {
+			m.Line = f.Loc.Line
+		}
+		mismatch(m)
+	}
+	return res
+}
+
+// matches reports whether f is the finding e expects. STEP.X is ambiguous
+// between DD X of STEP and PROC step STEP.X, so the target is compared with
+// both the finding's step and its step and DD.
+func matches(e Expectation, f ir.Finding) bool {
+	if !strings.EqualFold(e.Rule, f.RuleID) {
+		return false
+	}
+	target := e.String()[len(e.Rule):]
+	if target == "" {
+		return true
+	}
+	target = target[1:] // drop "@"
+	return strings.EqualFold(target, f.Step) || (f.DD != "" && strings.EqualFold(target, f.Step+"."+f.DD))
+}
+
+// RunDir runs every fixture (*.jcl) under dir, in path order.
+func RunDir(dir string, opts Options) ([]Result, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.EqualFold(filepath.Ext(p), FixtureExt) {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var out []Result
+	for _, file := range files {
+		fx, err := Load(file)
+		if err != nil {
+			return out, err
+		}
+		out = append(out, Run(fx, opts))
+	}
+	return out, nil
+}
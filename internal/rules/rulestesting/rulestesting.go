@@ -0,0 +1,29 @@
+// Package rulestesting runs rulestest fixtures from Go tests. It is kept
+// apart from rulestest so the jclift binary does not link package testing.
+package rulestesting
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/rules/rulestest"
+)
+
+// Test runs the fixtures under dir as subtests of t, one per fixture.
+func Test(t *testing.T, dir string, opts rulestest.Options) {
+	t.Helper()
+	results, err := rulestest.RunDir(dir, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) == 0 {
+		t.Fatalf("no %s fixtures under %s", rulestest.FixtureExt, dir)
+	}
+	for _, r := range results {
+		t.Run(filepath.Base(r.Fixture.File), func(t *testing.T) {
+			for _, m := range r.Mismatches {
+				t.Error(m)
+			}
+		})
+	}
+}
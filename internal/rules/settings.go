@@ -5,7 +5,12 @@ import "strings"
 type Settings struct {
 	SeverityThreshold         string
 	Disabled                  map[string]bool
-	SortwkPrimaryCylThreshold int
+	RecordDisabled            bool // still run disabled rules, keeping their findings as suppressed
+	SortwkPrimaryCylThreshold int  // legacy rules.sortwk; SORT-SORTWK-OVERSIZED primary_cyl_threshold unless Params sets it
+	Workers                   int  // jobs evaluated concurrently; GOMAXPROCS when <= 0
+
+	Severity map[string]string         // UPPER(rule ID) -> LOW|MEDIUM|HIGH, replacing the rule's severity
+	Params   map[string]map[string]any // UPPER(rule ID) -> parameter name -> value (see Rule.Params)
 }
 
 var rsettings = Settings{
@@ -25,9 +30,27 @@ func SetSettings(s Settings) {
 	if s.SortwkPrimaryCylThreshold == 0 {
 		s.SortwkPrimaryCylThreshold = rsettings.SortwkPrimaryCylThreshold
 	}
+	s.Severity = upperKeys(s.Severity)
+	s.Params = upperKeys(s.Params)
+	if _, ok := s.Params[sortwkRuleID]["primary_cyl_threshold"]; !ok {
+		p := map[string]any{"primary_cyl_threshold": s.SortwkPrimaryCylThreshold}
+		for k, v := range s.Params[sortwkRuleID] {
+			p[k] = v
+		}
+		s.Params[sortwkRuleID] = p
+	}
 	rsettings = s
 }
 
+// upperKeys copies m with rule IDs upper-cased.
+func upperKeys[V any](m map[string]V) map[string]V {
+	out := make(map[string]V, len(m))
+	for k, v := range m {
+		out[strings.ToUpper(strings.TrimSpace(k))] = v
+	}
+	return out
+}
+
 func severityRank(sev string) int {
 	switch strings.ToUpper(strings.TrimSpace(sev)) {
 	case "HIGH":
@@ -1,13 +1,68 @@
 package rules
 
-import "github.com/codewithboateng/jclift/internal/ir"
+import (
+	"strings"
 
-// Rule represents a single analysis rule executed over a Job.
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+// Rule represents a single analysis rule executed over a Job, or, when
+// EvalRun is set instead of Eval, once over the whole Run (cross-job rules).
+// FixRun, when set on a job rule, is called for each of its findings after
+// every job was evaluated, to attach a fix that depends on the rest of the
+// run (e.g. readers in other jobs); the finding itself stays job-scoped.
 type Rule struct {
 	ID               string
 	Summary          string
 	Type             string // "COST" | "RISK" (advisory)
 	DefaultSeverity  string // "LOW" | "MEDIUM" | "HIGH" (advisory)
 	Docs             string // URL or repo path to docs for this rule
+	Params           []Param // tunables, set per site via rules.<ID>.params
 	Eval             func(job *ir.Job) []ir.Finding
-}
\ No newline at end of file
+	EvalRun          func(run *ir.Run) []ir.Finding
+	FixRun           func(run *ir.Run, job *ir.Job, f *ir.Finding)
+}
+
+// Rule scopes, as reported by Rule.Scope.
+const (
+	ScopeJob = "job"
+	ScopeRun = "run"
+)
+
+// Scope reports whether the rule evaluates one job at a time or the run.
+func (r Rule) Scope() string {
+	if r.EvalRun != nil {
+		return ScopeRun
+	}
+	return ScopeJob
+}
+
+// allDDs flattens a step's DDs, expanding concatenations into their members.
+func allDDs(st ir.Step) []ir.DD {
+	var out []ir.DD
+	for _, dd := range st.DD {
+		out = append(out, dd.Members()...)
+	}
+	return out
+}
+
+// controlCards returns the records of the step's ddname DD, joining the
+// members of a concatenation. known is false when a member names a dataset
+// whose records were not loaded from a library mirror, so the cards cannot
+// be judged.
+func controlCards(st ir.Step, ddname string) (text string, found, known bool) {
+	for _, dd := range st.DD {
+		if !strings.EqualFold(dd.DDName, ddname) {
+			continue
+		}
+		known = true
+		for _, m := range dd.Members() {
+			if m.Dataset != "" && m.Content == "" {
+				known = false
+			}
+			text += m.Content
+		}
+		return text, true, known
+	}
+	return "", false, true
+}
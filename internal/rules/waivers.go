@@ -8,19 +8,20 @@ import (
 )
 
 // ApplyWaivers filters out findings that match any active waiver.
-// Returns (kept, waivedCount)
-func ApplyWaivers(in []ir.Finding, waivers []storage.Waiver) ([]ir.Finding, int) {
+// Returns (kept, waived) where each waived finding names its waiver.
+func ApplyWaivers(in []ir.Finding, waivers []storage.Waiver) ([]ir.Finding, []ir.SuppressedFinding) {
 	if len(waivers) == 0 || len(in) == 0 {
-		return in, 0
+		return in, nil
 	}
 	var out []ir.Finding
-	waived := 0
+	var waived []ir.SuppressedFinding
 nextFinding:
 	for _, f := range in {
 		for _, w := range waivers {
 			if !eqCI(f.RuleID, w.RuleID) { continue }
-			if w.Job != ""  && !eqCI(f.Job,  w.Job)  { continue }
+			if w.Job != ""  && !waiverJob(f, w.Job) { continue }
 			if w.Step != "" && !eqCI(f.Step, w.Step) { continue }
+			if w.Fingerprint != "" && !eqCI(f.Fingerprint, w.Fingerprint) { continue }
 			if w.PatternSub != "" {
 				ps := strings.ToUpper(w.PatternSub)
 				if !strings.Contains(strings.ToUpper(f.Evidence), ps) &&
@@ -29,7 +30,7 @@ nextFinding:
 				}
 			}
 			// matched → waive it
-			waived++
+			waived = append(waived, ir.SuppressedFinding{Finding: f, Reason: ir.SuppressedWaived, WaiverID: w.ID})
 			continue nextFinding
 		}
 		out = append(out, f)
@@ -37,4 +38,18 @@ nextFinding:
 	return out, waived
 }
 
+// waiverJob reports whether job is the finding's job or, for a cross-job
+// finding, any of the jobs it involves.
+func waiverJob(f ir.Finding, job string) bool {
+	if eqCI(f.Job, job) {
+		return true
+	}
+	for _, j := range f.Jobs {
+		if eqCI(j, job) {
+			return true
+		}
+	}
+	return false
+}
+
 func eqCI(a, b string) bool { return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b)) }
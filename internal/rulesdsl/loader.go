@@ -22,11 +22,18 @@ type dslRule struct {
 	Type     string `yaml:"type"`     // COST|RISK
 	Severity string `yaml:"severity"` // LOW|MEDIUM|HIGH
 	Message  string `yaml:"message"`
+	Scope    string `yaml:"scope"`    // job (default) | run
 
 	Where struct {
 		Program    string `yaml:"program"`      // regex (case-insensitive)
 		DDName     string `yaml:"ddname"`       // require a DD with this name (optional)
 		SysinRegex string `yaml:"sysin_regex"`  // regex on SYSIN text (optional)
+
+		// scope: run — one finding per dataset whose matching uses span
+		// at least min_jobs jobs
+		Dataset string `yaml:"dataset"`  // regex on the dataset name (optional)
+		Access  string `yaml:"access"`   // create|write|read (optional)
+		MinJobs int    `yaml:"min_jobs"` // default 2
 	} `yaml:"where"`
 
 	Savings struct {
@@ -39,6 +46,7 @@ type compiled struct {
 	rule        dslRule
 	reProgram   *regexp.Regexp
 	reSysin     *regexp.Regexp
+	reDataset   *regexp.Regexp
 	needDDName  string
 }
 
@@ -78,10 +86,47 @@ func compile(r dslRule) (*compiled, error) {
 		if err != nil { return nil, fmt.Errorf("sysin_regex: %w", err) }
 		c.reSysin = re
 	}
+
+	switch strings.ToLower(strings.TrimSpace(r.Scope)) {
+	case "", rules.ScopeJob:
+		if r.Where.Dataset != "" || r.Where.Access != "" || r.Where.MinJobs != 0 {
+			return nil, fmt.Errorf("dataset/access/min_jobs need scope: run")
+		}
+	case rules.ScopeRun:
+		if c.reSysin != nil {
+			return nil, fmt.Errorf("sysin_regex is not supported with scope: run")
+		}
+		switch strings.ToLower(r.Where.Access) {
+		case "", ir.AccessCreate, ir.AccessWrite, ir.AccessRead:
+		default:
+			return nil, fmt.Errorf("access must be create, write or read")
+		}
+		if r.Where.Dataset != "" {
+			re, err := regexp.Compile("(?i)" + r.Where.Dataset)
+			if err != nil { return nil, fmt.Errorf("dataset regex: %w", err) }
+			c.reDataset = re
+		}
+		if c.rule.Where.MinJobs <= 0 {
+			c.rule.Where.MinJobs = 2
+		}
+	default:
+		return nil, fmt.Errorf("scope must be job or run")
+	}
 	return c, nil
 }
 
 func registerCompiled(c compiled) {
+	if strings.EqualFold(strings.TrimSpace(c.rule.Scope), rules.ScopeRun) {
+		r := rules.Rule{
+			ID:              c.rule.ID,
+			Summary:         c.rule.Summary,
+			Type:            strings.ToUpper(c.rule.Type),
+			DefaultSeverity: strings.ToUpper(c.rule.Severity),
+		}
+		r.EvalRun = func(run *ir.Run) []ir.Finding { return evalRun(c, r, run) }
+		rules.Register(r)
+		return
+	}
 	rules.Register(rules.Rule{
 		ID:      c.rule.ID,
 		Summary: c.rule.Summary,
@@ -139,6 +184,55 @@ func registerCompiled(c compiled) {
 	})
 }
 
+// evalRun reports each dataset whose uses matching c span at least
+// min_jobs jobs, anchored at its first matching use. Grouping and the
+// finding itself come from the rules package, as for built-in cross-job
+// rules.
+func evalRun(c compiled, rule rules.Rule, run *ir.Run) []ir.Finding {
+	steps := map[string]ir.Step{}
+	for _, job := range run.Jobs {
+		for _, st := range job.Steps {
+			if _, ok := steps[job.Name+"."+st.Name]; !ok {
+				steps[job.Name+"."+st.Name] = st
+			}
+		}
+	}
+
+	groups := rules.GroupEdges(rules.RunLineage(run), func(e ir.LineageEdge) bool {
+		st := steps[e.Job+"."+e.Step]
+		if c.reDataset != nil && !c.reDataset.MatchString(e.Dataset) { return false }
+		if c.reProgram != nil && !c.reProgram.MatchString(st.Program) { return false }
+		if c.needDDName != "" && !strings.EqualFold(e.DD, c.needDDName) { return false }
+		if c.rule.Where.Access != "" && !strings.EqualFold(e.Access, c.rule.Where.Access) { return false }
+		return true
+	})
+
+	var out []ir.Finding
+	for _, grp := range groups {
+		if len(rules.EdgeJobs(grp.Edges)) < c.rule.Where.MinJobs {
+			continue
+		}
+		sav := 0.0
+		switch strings.ToLower(c.rule.Savings.Kind) {
+		case "mips":
+			sav = c.rule.Savings.MIPS
+		case "step_cost":
+			seen := map[string]bool{}
+			for _, e := range grp.Edges {
+				if key := e.Job + "." + e.Step; !seen[key] {
+					seen[key] = true
+					sav += steps[key].Annotations.Cost.MIPS
+				}
+			}
+		}
+		f := rules.CrossJobFinding(rule, grp, c.rule.Message)
+		f.Severity = strings.ToUpper(c.rule.Severity)
+		f.SavingsMIPS = sav
+		out = append(out, f)
+	}
+	return out
+}
+
 func evidenceFor(st ir.Step, c compiled) string {
 	parts := []string{"PGM=" + st.Program}
 	if c.needDDName != "" {
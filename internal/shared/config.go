@@ -14,8 +14,22 @@ type Config struct {
 	} `yaml:"database"`
 
 	Analysis struct {
-		Sources   []string `yaml:"sources"`
+		Sources   []string `yaml:"sources"` // job directories (or files), all analyzed in order
+		Include   []string `yaml:"include"` // globs selecting job members, e.g. ["*"] for extensionless members
+		Exclude   []string `yaml:"exclude"` // globs for files/directories to skip, e.g. ["**/backup/**"]
+		Libraries []struct {
+			Dir     string `yaml:"dir"`
+			Role    string `yaml:"role"`    // jobs|procs|includes|sysin
+			Dataset string `yaml:"dataset"` // PDS mirrored by dir
+		} `yaml:"libraries"` // directories by role; procs/includes extend proclibs, sysin mirrors control-card datasets (dir may be a file for a sequential dataset)
 		MIPSToUSD float64  `yaml:"mips_to_usd"`
+		ProcLibs  []struct {
+			Dir     string `yaml:"dir"`
+			Dataset string `yaml:"dataset"` // PDS mirrored by dir; matched against JCLLIB ORDER
+		} `yaml:"proclibs"` // cataloged PROC/INCLUDE libraries, in search order
+		Symbols  map[string]string `yaml:"symbols"`  // site system symbols, e.g. SYSUID: BATCHID
+		Encoding string            `yaml:"encoding"` // auto|ascii|ibm1047|ibm037 (EBCDIC, FB-80, XMIT/IEBCOPY unload)
+		Workers  int               `yaml:"workers"`  // parse/cost/rules workers; 0 = number of CPUs
 	} `yaml:"analysis"`
 
 	Reporting struct {
@@ -30,9 +44,13 @@ type Config struct {
 	Rules struct {
 		SeverityThreshold string   `yaml:"severity_threshold"` // LOW|MEDIUM|HIGH
 		Disable           []string `yaml:"disable"`            // ["RULE-ID", ...]
+		RecordDisabled    bool     `yaml:"record_disabled"`    // run disabled rules; findings suppressed as "disabled"
 		Sortwk            struct {
 			PrimaryCylThreshold int `yaml:"primary_cyl_threshold"` // default 500
-		} `yaml:"sortwk"`
+		} `yaml:"sortwk"` // legacy; prefer SORT-SORTWK-OVERSIZED.params.primary_cyl_threshold
+
+		// Per rule, keyed by rule ID: RULE-ID: {severity: HIGH, params: {...}}
+		PerRule map[string]RuleConfig `yaml:",inline"`
 	} `yaml:"rules"`
 
 	Cost struct {
@@ -58,6 +76,12 @@ type Config struct {
 	} `yaml:"cost"`
 }
 
+// RuleConfig overrides one rule's severity and parameters.
+type RuleConfig struct {
+	Severity string         `yaml:"severity"` // LOW|MEDIUM|HIGH
+	Params   map[string]any `yaml:"params"`   // see GET /api/v1/rules/meta for each rule's parameters
+}
+
 func DefaultConfig() Config {
 	var c Config
 	c.Database.Driver = "sqlite"
@@ -0,0 +1,43 @@
+package shared
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Workers returns n, or GOMAXPROCS when n <= 0.
+func Workers(n int) int {
+	if n <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return n
+}
+
+// ParallelFor calls fn(i) for every i in [0, n) on at most workers
+// goroutines (GOMAXPROCS when workers <= 0) and waits for all calls. Callers
+// write results into index i to keep output order deterministic.
+func ParallelFor(n, workers int, fn func(i int)) {
+	workers = min(Workers(workers), n)
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
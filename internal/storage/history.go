@@ -0,0 +1,297 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+)
+
+// historyLayout is fixed-width UTC so history timestamps compare as text.
+const historyLayout = "2006-01-02T15:04:05.000000000Z"
+
+func historyTime(t time.Time) string { return t.UTC().Format(historyLayout) }
+
+// historySchema is the finding_history table, part of CreateSchema.
+const historySchema = `
+-- One row per finding fingerprint and run source: a finding is resolved
+-- only by a later run of the same source. Timestamps are the runs'
+-- started_at in historyTime's fixed-width UTC form so they compare as text.
+CREATE TABLE IF NOT EXISTS finding_history (
+  fingerprint    TEXT NOT NULL,
+  rule_id        TEXT NOT NULL,
+  job            TEXT,
+  step           TEXT,
+  dd             TEXT,
+  source         TEXT NOT NULL DEFAULT '',
+  first_seen_run TEXT NOT NULL,
+  first_seen_at  TEXT NOT NULL,
+  last_seen_run  TEXT NOT NULL,
+  last_seen_at   TEXT NOT NULL,
+  occurrences    INTEGER NOT NULL DEFAULT 0,
+  resolved_run   TEXT,              -- NULL = open
+  resolved_at    TEXT,
+  regressions    INTEGER NOT NULL DEFAULT 0,
+  regressed_run  TEXT,
+  regressed_at   TEXT,
+  PRIMARY KEY (source, fingerprint)
+);
+
+CREATE INDEX IF NOT EXISTS idx_history_open ON finding_history(source, resolved_at);
+`
+
+// FindingOccurrence is one run a finding fingerprint was seen in.
+type FindingOccurrence struct {
+	RunID      string    `json:"run_id"`
+	SeenAt     time.Time `json:"seen_at"`
+	Severity   string    `json:"severity,omitempty"`
+	Suppressed string    `json:"suppressed,omitempty"` // suppression reason; empty = reported
+}
+
+// saveHistory records the run's findings, reported and suppressed, in the
+// finding history inside tx. Fingerprints of the run's source that were
+// open before the run and are missing from it are marked resolved; a
+// resolved fingerprint seen again counts as a regression. Saving a run
+// again does not count its findings twice.
+func saveHistory(tx *sql.Tx, run *ir.Run) error {
+	type seenFinding struct {
+		f          ir.Finding
+		suppressed string
+	}
+	seen := map[string]seenFinding{}
+	for _, f := range run.Findings {
+		if f.Fingerprint != "" {
+			seen[f.Fingerprint] = seenFinding{f: f}
+		}
+	}
+	for _, sf := range run.SuppressedFindings {
+		if _, ok := seen[sf.Fingerprint]; !ok && sf.Fingerprint != "" {
+			seen[sf.Fingerprint] = seenFinding{f: sf.Finding, suppressed: sf.Reason}
+		}
+	}
+	prints := make([]string, 0, len(seen))
+	for fp := range seen {
+		prints = append(prints, fp)
+	}
+	sort.Strings(prints)
+
+	stored := map[string]bool{}
+	rows, err := tx.Query(`SELECT fingerprint FROM finding_occurrences WHERE run_id = ?`, run.ID)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var fp string
+		if err := rows.Scan(&fp); err != nil {
+			rows.Close()
+			return err
+		}
+		stored[fp] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM finding_occurrences WHERE run_id = ?`, run.ID); err != nil {
+		return err
+	}
+
+	at := historyTime(run.StartedAt)
+	for _, fp := range prints {
+		s := seen[fp]
+		var suppressed any
+		if s.suppressed != "" {
+			suppressed = s.suppressed
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO finding_occurrences (fingerprint, run_id, seen_at, severity, suppressed)
+			VALUES (?, ?, ?, ?, ?)`, fp, run.ID, at, s.f.Severity, suppressed); err != nil {
+			return err
+		}
+		if stored[fp] {
+			continue
+		}
+		if err := touchHistory(tx, run, at, s.f); err != nil {
+			return err
+		}
+	}
+
+	// Everything seen in this run now has last_seen_at >= at.
+	_, err = tx.Exec(`
+		UPDATE finding_history SET resolved_run = ?, resolved_at = ?
+		 WHERE source = ? AND resolved_at IS NULL AND last_seen_at < ?`,
+		run.ID, at, run.Source, at)
+	return err
+}
+
+// touchHistory counts one more occurrence of f, seen in run at at, in the
+// history of the run's source.
+func touchHistory(tx *sql.Tx, run *ir.Run, at string, f ir.Finding) error {
+	var firstAt, lastAt string
+	var resolvedAt sql.NullString
+	err := tx.QueryRow(`
+		SELECT first_seen_at, last_seen_at, resolved_at
+		  FROM finding_history WHERE source = ? AND fingerprint = ?`,
+		run.Source, f.Fingerprint).Scan(&firstAt, &lastAt, &resolvedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		_, err = tx.Exec(`
+			INSERT INTO finding_history
+			(fingerprint, rule_id, job, step, dd, source,
+			 first_seen_run, first_seen_at, last_seen_run, last_seen_at, occurrences)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1)`,
+			f.Fingerprint, f.RuleID, f.Job, f.Step, f.DD, run.Source, run.ID, at, run.ID, at)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`UPDATE finding_history SET occurrences = occurrences + 1 WHERE source = ? AND fingerprint = ?`,
+		run.Source, f.Fingerprint); err != nil {
+		return err
+	}
+	if at < firstAt {
+		if _, err := tx.Exec(`UPDATE finding_history SET first_seen_run = ?, first_seen_at = ? WHERE source = ? AND fingerprint = ?`,
+			run.ID, at, run.Source, f.Fingerprint); err != nil {
+			return err
+		}
+	}
+	if at >= lastAt {
+		if _, err := tx.Exec(`
+			UPDATE finding_history SET last_seen_run = ?, last_seen_at = ?, job = ?, step = ?, dd = ?
+			 WHERE source = ? AND fingerprint = ?`,
+			run.ID, at, f.Job, f.Step, f.DD, run.Source, f.Fingerprint); err != nil {
+			return err
+		}
+	}
+	if resolvedAt.Valid && at >= resolvedAt.String {
+		if _, err := tx.Exec(`
+			UPDATE finding_history
+			   SET resolved_run = NULL, resolved_at = NULL,
+			       regressions = regressions + 1, regressed_run = ?, regressed_at = ?
+			 WHERE source = ? AND fingerprint = ?`, run.ID, at, run.Source, f.Fingerprint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const historyColumns = `
+	h.fingerprint, h.rule_id, COALESCE(h.job, ''), COALESCE(h.step, ''), COALESCE(h.dd, ''),
+	h.source, h.first_seen_run, h.first_seen_at, h.last_seen_run, h.last_seen_at,
+	h.occurrences, COALESCE(h.resolved_run, ''), h.resolved_at,
+	h.regressions, COALESCE(h.regressed_run, ''), h.regressed_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanHistory(row rowScanner) (ir.FindingHistory, error) {
+	var h ir.FindingHistory
+	var firstAt, lastAt string
+	var resolvedAt, regressedAt sql.NullString
+	if err := row.Scan(&h.Fingerprint, &h.RuleID, &h.Job, &h.Step, &h.DD,
+		&h.Source, &h.FirstSeenRun, &firstAt, &h.LastSeenRun, &lastAt,
+		&h.Occurrences, &h.ResolvedRun, &resolvedAt,
+		&h.Regressions, &h.RegressedRun, &regressedAt); err != nil {
+		return ir.FindingHistory{}, err
+	}
+	h.FirstSeenAt = parseHistoryTime(firstAt)
+	h.LastSeenAt = parseHistoryTime(lastAt)
+	if resolvedAt.Valid {
+		t := parseHistoryTime(resolvedAt.String)
+		h.ResolvedAt = &t
+	}
+	if regressedAt.Valid {
+		t := parseHistoryTime(regressedAt.String)
+		h.RegressedAt = &t
+	}
+	return h, nil
+}
+
+func parseHistoryTime(s string) time.Time {
+	t, _ := time.Parse(historyLayout, s)
+	return t
+}
+
+// LoadFindingHistory returns the lifecycle of one finding fingerprint in
+// the runs of source (sql.ErrNoRows if it was never seen there).
+func (db *DB) LoadFindingHistory(source, fingerprint string) (ir.FindingHistory, error) {
+	return scanHistory(db.conn.QueryRow(`SELECT `+historyColumns+`
+		  FROM finding_history h WHERE h.source = ? AND h.fingerprint = ?`, source, fingerprint))
+}
+
+// ListFindingHistory returns the lifecycle of one finding fingerprint in
+// every source it was seen in, most recently seen first.
+func (db *DB) ListFindingHistory(fingerprint string) ([]ir.FindingHistory, error) {
+	rows, err := db.conn.Query(`SELECT `+historyColumns+`
+		  FROM finding_history h WHERE h.fingerprint = ?
+		 ORDER BY h.last_seen_at DESC, h.source`, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []ir.FindingHistory
+	for rows.Next() {
+		h, err := scanHistory(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, h)
+	}
+	return out, rows.Err()
+}
+
+// ListFindingOccurrences returns the runs of source a fingerprint was seen
+// in, oldest first.
+func (db *DB) ListFindingOccurrences(source, fingerprint string) ([]FindingOccurrence, error) {
+	rows, err := db.conn.Query(`
+		SELECT o.run_id, o.seen_at, COALESCE(o.severity, ''), COALESCE(o.suppressed, '')
+		  FROM finding_occurrences o
+		  JOIN runs r ON r.id = o.run_id
+		 WHERE o.fingerprint = ? AND COALESCE(r.source, '') = ?
+		 ORDER BY o.seen_at, o.run_id`, fingerprint, source)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []FindingOccurrence
+	for rows.Next() {
+		var o FindingOccurrence
+		var seenAt string
+		if err := rows.Scan(&o.RunID, &seenAt, &o.Severity, &o.Suppressed); err != nil {
+			return nil, err
+		}
+		o.SeenAt = parseHistoryTime(seenAt)
+		out = append(out, o)
+	}
+	return out, rows.Err()
+}
+
+// LoadRunHistory returns the lifecycle of every finding seen in a run, in
+// the run's source, keyed by fingerprint.
+func (db *DB) LoadRunHistory(runID string) (map[string]ir.FindingHistory, error) {
+	rows, err := db.conn.Query(`SELECT `+historyColumns+`
+		  FROM finding_occurrences o
+		  JOIN runs r ON r.id = o.run_id
+		  JOIN finding_history h ON h.fingerprint = o.fingerprint AND h.source = COALESCE(r.source, '')
+		 WHERE o.run_id = ?`, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := map[string]ir.FindingHistory{}
+	for rows.Next() {
+		h, err := scanHistory(rows)
+		if err != nil {
+			return nil, err
+		}
+		out[h.Fingerprint] = h
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"database/sql"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/lineage"
+)
+
+// saveLineage (re)writes the lineage edges of run inside tx.
+func saveLineage(tx *sql.Tx, run *ir.Run) error {
+	if _, err := tx.Exec(`DELETE FROM lineage_edges WHERE run_id = ?`, run.ID); err != nil {
+		return err
+	}
+	if run.Lineage == nil || len(run.Lineage.Edges) == 0 {
+		return nil
+	}
+	stmt, err := tx.Prepare(`
+		INSERT INTO lineage_edges
+		(run_id, seq, dataset, generation, job, step, dd, access, disp,
+		 disp_status, disp_normal, disp_abnormal, file, line)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for i, e := range run.Lineage.Edges {
+		loc := ir.Location{}
+		if e.Loc != nil {
+			loc = *e.Loc
+		}
+		if _, err := stmt.Exec(run.ID, i, e.Dataset, e.Generation, e.Job, e.Step, e.DD,
+			e.Access, e.Disp, e.Disposition.Status, e.Disposition.Normal, e.Disposition.Abnormal,
+			loc.File, loc.Line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadLineage returns the lineage graph stored for a run. Runs saved
+// without lineage edges get it rebuilt from their stored jobs.
+func (db *DB) LoadLineage(runID string) (*ir.Lineage, error) {
+	rows, err := db.conn.Query(`
+		SELECT dataset, COALESCE(generation, ''), job, step, dd, access,
+		       COALESCE(disp, ''), COALESCE(disp_status, ''), COALESCE(disp_normal, ''),
+		       COALESCE(disp_abnormal, ''), COALESCE(file, ''), COALESCE(line, 0)
+		  FROM lineage_edges
+		 WHERE run_id = ?
+		 ORDER BY seq`, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var edges []ir.LineageEdge
+	for rows.Next() {
+		var e ir.LineageEdge
+		var loc ir.Location
+		if err := rows.Scan(&e.Dataset, &e.Generation, &e.Job, &e.Step, &e.DD, &e.Access,
+			&e.Disp, &e.Disposition.Status, &e.Disposition.Normal, &e.Disposition.Abnormal,
+			&loc.File, &loc.Line); err != nil {
+			return nil, err
+		}
+		if loc.File != "" {
+			e.Loc = &loc
+		}
+		edges = append(edges, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(edges) == 0 {
+		run, err := db.LoadRun(runID)
+		if err != nil {
+			return nil, err
+		}
+		return lineage.Build(&run), nil
+	}
+	return lineage.FromEdges(edges), nil
+}
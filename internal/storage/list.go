@@ -2,6 +2,7 @@ package storage
 
 import (
 	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/codewithboateng/jclift/internal/ir"
@@ -45,7 +46,9 @@ func (db *DB) ListRuns(limit, offset int) ([]RunRow, error) {
 // ListFindings returns findings for a run at or above a minimum severity.
 func (db *DB) ListFindings(runID, minSeverity string) ([]ir.Finding, error) {
 	const q = `
-		SELECT id, job, step, rule_id, type, severity, message, evidence, savings_mips, savings_usd
+		SELECT id, COALESCE(fingerprint, ''), job, COALESCE(jobs, ''), step, COALESCE(dd, ''), COALESCE(dataset, ''), COALESCE(file, ''),
+		       COALESCE(line, 0), COALESCE(end_line, 0), COALESCE(col, 0), COALESCE(end_col, 0),
+		       rule_id, type, severity, message, evidence, savings_mips, savings_usd
 		  FROM findings
 		 WHERE run_id = ?
 		   AND (CASE severity WHEN 'HIGH' THEN 3 WHEN 'MEDIUM' THEN 2 ELSE 1 END)
@@ -62,9 +65,19 @@ func (db *DB) ListFindings(runID, minSeverity string) ([]ir.Finding, error) {
 	var out []ir.Finding
 	for rows.Next() {
 		var f ir.Finding
-		if err := rows.Scan(&f.ID, &f.Job, &f.Step, &f.RuleID, &f.Type, &f.Severity, &f.Message, &f.Evidence, &f.SavingsMIPS, &f.SavingsUSD); err != nil {
+		var loc ir.Location
+		var jobs string
+		if err := rows.Scan(&f.ID, &f.Fingerprint, &f.Job, &jobs, &f.Step, &f.DD, &f.Dataset, &loc.File,
+			&loc.Line, &loc.EndLine, &loc.Col, &loc.EndCol,
+			&f.RuleID, &f.Type, &f.Severity, &f.Message, &f.Evidence, &f.SavingsMIPS, &f.SavingsUSD); err != nil {
 			return nil, err
 		}
+		if loc.File != "" {
+			f.Loc = &loc
+		}
+		if jobs != "" {
+			f.Jobs = strings.Split(jobs, ",")
+		}
 		out = append(out, f)
 	}
 	return out, rows.Err()
@@ -78,7 +91,7 @@ func (db *DB) LoadLatestRun() (ir.Run, error) {
 	return db.LoadRun(id)
 }
 
-// Optional helper used by future endpoints.
+// HasRun reports whether a run is stored, without loading it.
 func (db *DB) HasRun(id string) (bool, error) {
 	const q = `SELECT 1 FROM runs WHERE id = ? LIMIT 1`
 	var one int
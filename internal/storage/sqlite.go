@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 
 	_ "modernc.org/sqlite" // CGO-free SQLite driver
@@ -43,8 +44,17 @@ CREATE TABLE IF NOT EXISTS runs (
 CREATE TABLE IF NOT EXISTS findings (
   id           TEXT,
   run_id       TEXT NOT NULL,
+  fingerprint  TEXT,
   job          TEXT,
+  jobs         TEXT,
   step         TEXT,
+  dd           TEXT,
+  dataset      TEXT,
+  file         TEXT,
+  line         INTEGER,
+  end_line     INTEGER,
+  col          INTEGER,
+  end_col      INTEGER,
   rule_id      TEXT,
   type         TEXT,
   severity     TEXT,
@@ -59,6 +69,38 @@ CREATE TABLE IF NOT EXISTS findings (
 CREATE INDEX IF NOT EXISTS idx_findings_run ON findings(run_id);
 CREATE INDEX IF NOT EXISTS idx_findings_rule ON findings(rule_id);
 
+CREATE TABLE IF NOT EXISTS lineage_edges (
+  run_id     TEXT NOT NULL,
+  seq        INTEGER NOT NULL,  -- edge order within the run
+  dataset    TEXT NOT NULL,     -- GDG base; JOB.&&NAME for temporary datasets
+  generation TEXT,              -- GDG relative generation (+1, 0, -1)
+  job        TEXT,
+  step       TEXT,
+  dd         TEXT,
+  access     TEXT,              -- create|write|read
+  disp       TEXT,              -- as coded
+  disp_status   TEXT,           -- parsed DISP; NEW when omitted
+  disp_normal   TEXT,
+  disp_abnormal TEXT,
+  file       TEXT,
+  line       INTEGER,
+  PRIMARY KEY (run_id, seq),
+  FOREIGN KEY(run_id) REFERENCES runs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_lineage_dataset ON lineage_edges(dataset);
+
+` + historySchema + `
+CREATE TABLE IF NOT EXISTS finding_occurrences (
+  fingerprint TEXT NOT NULL,
+  run_id      TEXT NOT NULL,
+  seen_at     TEXT NOT NULL,
+  severity    TEXT,
+  suppressed  TEXT,                 -- suppression reason; NULL = reported
+  PRIMARY KEY (fingerprint, run_id),
+  FOREIGN KEY(run_id) REFERENCES runs(id) ON DELETE CASCADE
+);
+
 CREATE TABLE IF NOT EXISTS users (
   id INTEGER PRIMARY KEY AUTOINCREMENT,
   username TEXT UNIQUE NOT NULL,
@@ -90,6 +132,7 @@ CREATE TABLE IF NOT EXISTS waivers (
   job         TEXT,              -- optional exact match; NULL = any
   step        TEXT,              -- optional exact match; NULL = any
   pattern_sub TEXT,              -- optional substring to match evidence/message
+  fingerprint TEXT,              -- optional exact finding fingerprint; NULL = any
   reason      TEXT NOT NULL,
   expires_at  TEXT NOT NULL,     -- RFC3339Nano
   created_by  TEXT NOT NULL,
@@ -114,10 +157,54 @@ WHERE job IS NOT NULL AND step IS NOT NULL;
 	if err != nil {
 		return err
 	}
+	// Databases created before findings carried source locations,
+	// cross-job findings their jobs and findings fingerprints and datasets.
+	if err := db.addColumns("findings", []string{
+		"dd TEXT", "file TEXT", "line INTEGER", "end_line INTEGER", "col INTEGER", "end_col INTEGER",
+		"jobs TEXT", "fingerprint TEXT", "dataset TEXT",
+	}); err != nil {
+		return err
+	}
+	if err := db.addColumns("waivers", []string{"fingerprint TEXT"}); err != nil {
+		return err
+	}
+	_, err = db.conn.Exec(`CREATE INDEX IF NOT EXISTS idx_findings_fingerprint ON findings(fingerprint)`)
+	return err
+}
+
+// addColumns adds each "name TYPE" column missing from table.
+func (db *DB) addColumns(table string, cols []string) error {
+	rows, err := db.conn.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return err
+	}
+	have := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		have[name] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, c := range cols {
+		name, _, _ := strings.Cut(c, " ")
+		if have[name] {
+			continue
+		}
+		if _, err := db.conn.Exec(`ALTER TABLE ` + table + ` ADD COLUMN ` + c); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// SaveRun upserts a run JSON and (re)writes its findings.
+// SaveRun upserts a run JSON, (re)writes its findings and lineage and
+// updates the finding history.
 func (db *DB) SaveRun(run *ir.Run) error {
 	b, err := json.Marshal(run)
 	if err != nil {
@@ -143,21 +230,41 @@ func (db *DB) SaveRun(run *ir.Run) error {
 	if _, err := tx.Exec(`DELETE FROM findings WHERE run_id = ?`, run.ID); err != nil {
 		return err
 	}
+	if err := saveLineage(tx, run); err != nil {
+		return err
+	}
+	if err := saveHistory(tx, run); err != nil {
+		return err
+	}
 	if len(run.Findings) > 0 {
 		stmt, err := tx.Prepare(`
 			INSERT INTO findings
-			(id, run_id, job, step, rule_id, type, severity, message, evidence, savings_mips, savings_usd)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+			(id, run_id, fingerprint, job, jobs, step, dd, dataset, file, line, end_line, col, end_col,
+			 rule_id, type, severity, message, evidence, savings_mips, savings_usd)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 		if err != nil {
 			return err
 		}
 		defer stmt.Close()
 		for _, f := range run.Findings {
+			loc := ir.Location{}
+			if f.Loc != nil {
+				loc = *f.Loc
+			}
 			if _, err := stmt.Exec(
 				f.ID,
 				run.ID,
+				f.Fingerprint,
 				f.Job,
+				strings.Join(f.Jobs, ","),
 				f.Step,
+				f.DD,
+				f.Dataset,
+				loc.File,
+				loc.Line,
+				loc.EndLine,
+				loc.Col,
+				loc.EndCol,
 				f.RuleID,
 				f.Type,
 				f.Severity,
@@ -11,6 +11,7 @@ type Waiver struct {
 	Job        string    `json:"job,omitempty"`
 	Step       string    `json:"step,omitempty"`
 	PatternSub string    `json:"pattern_sub,omitempty"`
+	Fingerprint string   `json:"fingerprint,omitempty"` // waives exactly one finding identity
 	Reason     string    `json:"reason"`
 	ExpiresAt  time.Time `json:"expires_at"`
 	CreatedBy  string    `json:"created_by"`
@@ -18,12 +19,12 @@ type Waiver struct {
 	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
 }
 
-func (db *DB) CreateWaiver(ruleID, job, step, pattern, reason, createdBy string, expires time.Time) (int64, error) {
+func (db *DB) CreateWaiver(ruleID, job, step, pattern, fingerprint, reason, createdBy string, expires time.Time) (int64, error) {
 	now := time.Now().UTC().Format(time.RFC3339Nano)
 	res, err := db.conn.Exec(`
-INSERT INTO waivers(rule_id, job, step, pattern_sub, reason, expires_at, created_by, created_at)
-VALUES(?,?,?,?,?,?,?,?)`,
-		ruleID, nz(job), nz(step), nz(pattern), reason, expires.UTC().Format(time.RFC3339Nano), createdBy, now)
+INSERT INTO waivers(rule_id, job, step, pattern_sub, fingerprint, reason, expires_at, created_by, created_at)
+VALUES(?,?,?,?,?,?,?,?,?)`,
+		ruleID, nz(job), nz(step), nz(pattern), nz(fingerprint), reason, expires.UTC().Format(time.RFC3339Nano), createdBy, now)
 	if err != nil { return 0, err }
 	return res.LastInsertId()
 }
@@ -38,7 +39,7 @@ func (db *DB) RevokeWaiver(id int64, by string) error {
 func (db *DB) ListWaivers(activeOnly bool) ([]Waiver, error) {
 	q := `
 SELECT id, rule_id, COALESCE(job,''), COALESCE(step,''), COALESCE(pattern_sub,''),
-       reason, expires_at, created_by, created_at, revoked_at
+       COALESCE(fingerprint,''), reason, expires_at, created_by, created_at, revoked_at
 FROM waivers`
 	args := []any{}
 	if activeOnly {
@@ -55,12 +56,12 @@ FROM waivers`
 		var (
 			w Waiver
 			exp, ca, ra sql.NullString
-			job, step, pat string
+			job, step, pat, fp string
 		)
-		if err := rows.Scan(&w.ID, &w.RuleID, &job, &step, &pat, &w.Reason, &exp, &w.CreatedBy, &ca, &ra); err != nil {
+		if err := rows.Scan(&w.ID, &w.RuleID, &job, &step, &pat, &fp, &w.Reason, &exp, &w.CreatedBy, &ca, &ra); err != nil {
 			return nil, err
 		}
-		w.Job, w.Step, w.PatternSub = job, step, pat
+		w.Job, w.Step, w.PatternSub, w.Fingerprint = job, step, pat, fp
 		if exp.Valid { if t, e := time.Parse(time.RFC3339Nano, exp.String); e == nil { w.ExpiresAt = t } }
 		if ca.Valid  { if t, e := time.Parse(time.RFC3339Nano, ca.String); e == nil { w.CreatedAt = t } }
 		if ra.Valid  { if t, e := time.Parse(time.RFC3339Nano, ra.String); e == nil { w.RevokedAt = &t } }
@@ -0,0 +1,60 @@
+package golden
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/cost"
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/parser"
+	"github.com/codewithboateng/jclift/internal/rules"
+)
+
+const concurrencyJob = `//JOBNAME  JOB (1),'PAR',CLASS=A
+//S1       EXEC PGM=SORT
+//SORTIN   DD DSN=PROD.IN,DISP=SHR
+//SORTOUT  DD DSN=PROD.OUT,DISP=(NEW,CATLG),SPACE=(CYL,(10,5))
+//SYSIN    DD *
+  SORT FIELDS=COPY
+/*
+//S2       EXEC PGM=IEBGENER
+//SYSUT1   DD DSN=PROD.OUT,DISP=SHR
+//SYSUT2   DD DSN=PROD.COPY,DISP=(NEW,CATLG)
+//S3       EXEC PGM=MISSING,BAD=1
+`
+
+// TestPipeline_ConcurrentMatchesSequential checks that parse, cost and rule
+// evaluation produce identical runs whatever the worker count.
+func TestPipeline_ConcurrentMatchesSequential(t *testing.T) {
+	dir := t.TempDir()
+	for i := 0; i < 200; i++ {
+		body := strings.Replace(concurrencyJob, "JOBNAME ", fmt.Sprintf("JOB%05d", i), 1)
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("m%03d.jcl", i)), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	analyze := func(workers int) ir.Run {
+		rules.SetSettings(rules.Settings{Workers: workers})
+		defer rules.SetSettings(rules.Settings{})
+		run, _ := parser.ParseWithOptions(dir, parser.Options{Workers: workers})
+		cost.Annotate(&run, workers)
+		run.Findings = rules.Evaluate(&run)
+		for i := range run.Context.RuleStats { // timings vary; counts must not
+			run.Context.RuleStats[i].Elapsed, run.Context.RuleStats[i].Max = 0, 0
+		}
+		return run
+	}
+	seq, par := analyze(1), analyze(8)
+	if len(seq.Jobs) != 200 || len(seq.Findings) == 0 || len(seq.Diagnostics) == 0 {
+		t.Fatalf("unexpected baseline: %d jobs, %d findings, %d diagnostics",
+			len(seq.Jobs), len(seq.Findings), len(seq.Diagnostics))
+	}
+	if !reflect.DeepEqual(seq, par) {
+		t.Errorf("concurrent run differs from sequential run")
+	}
+}
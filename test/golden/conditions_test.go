@@ -0,0 +1,77 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/parser"
+)
+
+const condJob = `//CONDJOB  JOB (1),'COND',CLASS=A
+//S1       EXEC PGM=IEFBR14
+//S2       EXEC PGM=IEBGENER,COND=((4,LT),(8,GT,S1))
+//CHK      IF (S1.RC <= 4 & ¬S1.ABEND) |
+//            S2.RC = 0 THEN
+//S3       EXEC PGM=SORT
+//         ELSE
+//S4       EXEC PGM=IEFBR14,COND=EVEN
+//         ENDIF
+//         IF ABEND THEN
+//CLEANUP  EXEC PGM=IEFBR14
+//         ENDIF
+//S6       EXEC PGM=IEFBR14
+`
+
+func TestParser_Conditions(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "condjob.jcl"), []byte(condJob), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run, diags := parser.Parse(dir)
+	if len(run.Jobs) != 1 || len(run.Jobs[0].Steps) != 6 {
+		t.Fatalf("unexpected parse result: %+v (warnings=%v)", run.Jobs, diags.Items)
+	}
+	if len(diags.Items) != 0 {
+		t.Errorf("unexpected warnings: %v", diags.Items)
+	}
+	steps := run.Jobs[0].Steps
+
+	c := steps[1].Cond
+	if c == nil || len(c.Tests) != 2 || c.Tests[0].Code != 4 || c.Tests[0].Operator != "LT" ||
+		c.Tests[1].Step != "S1" || c.Tests[1].Operator != "GT" {
+		t.Errorf("S2 COND not parsed: %+v", c)
+	}
+
+	s3 := steps[2]
+	if len(s3.When) != 1 || s3.When[0].Name != "CHK" || s3.When[0].Else {
+		t.Fatalf("S3 IF clause: %+v", s3.When)
+	}
+	e := s3.When[0].Expr
+	if e == nil || e.Op != "OR" || e.Left.Op != "AND" || e.Right.Keyword != "RC" || e.Right.Step != "S2" {
+		t.Fatalf("S3 expression tree: %+v", e)
+	}
+	if rel := e.Left.Left; rel.Keyword != "RC" || rel.Step != "S1" || rel.Relation != "LE" || rel.Value != "4" {
+		t.Errorf("S1.RC <= 4 parsed as %+v", rel)
+	}
+	if not := e.Left.Right; not.Op != "NOT" || not.Left.Keyword != "ABEND" {
+		t.Errorf("¬S1.ABEND parsed as %+v", not)
+	}
+
+	s4 := steps[3]
+	if len(s4.When) != 1 || !s4.When[0].Else || s4.Cond == nil || !s4.Cond.Even {
+		t.Errorf("S4 should sit in the ELSE branch with COND=EVEN: %+v %+v", s4.When, s4.Cond)
+	}
+	if len(steps[5].When) != 0 {
+		t.Errorf("S6 should be outside any IF: %+v", steps[5].When)
+	}
+
+	// After S1 abends: S3 may run (S2.RC is unknown), S4 may run (ELSE with
+	// COND=EVEN), CLEANUP runs (IF ABEND) and S6 is bypassed.
+	want := map[string]bool{"S3": true, "S4": true, "CLEANUP": true, "S6": false}
+	for _, st := range steps[2:] {
+		if got := st.MayRunAfterAbend("S1"); got != want[st.Name] {
+			t.Errorf("%s MayRunAfterAbend(S1)=%v, want %v", st.Name, got, want[st.Name])
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/parser"
+)
+
+const continuationGolden = "test/golden/continuation.json"
+
+// numbered pads each card to 72 columns and stamps a sequence number in
+// columns 73-80, the way members come out of ISPF with NUM ON.
+func numbered(cards ...string) string {
+	var b strings.Builder
+	for i, c := range cards {
+		fmt.Fprintf(&b, "%-72s%08d\n", c, (i+1)*100)
+	}
+	return b.String()
+}
+
+func sampleContinued() string {
+	// A quoted PARM running to column 71 and resuming in column 16.
+	parm := "//S2       EXEC PGM=IKJEFT01,PARM='%" + "LONGCMD ARG1 ARG2"
+	parm += strings.Repeat("X", 71-len(parm))
+
+	return numbered(
+		"//CONTJOB  JOB (1),'CONT',CLASS=A",
+		"//S1       EXEC PGM=SORT,",
+		"//             COND=(4,LT)",
+		"//SORTIN   DD DSN=PROD.PAYROLL.MASTER,",
+		"//            DISP=SHR",
+		"//SORTOUT  DD DSN=PROD.PAYROLL.SORTED,     OUTPUT DATASET",
+		"//            DISP=(NEW,CATLG,DELETE),",
+		"//* a comment card between continuations",
+		"//            SPACE=(CYL,(50,10),RLSE),",
+		"//            DCB=(RECFM=FB,LRECL=80)",
+		"//SYSIN    DD *",
+		"  SORT FIELDS=(1,10,CH,A)",
+		"/*",
+		parm,
+		"//"+strings.Repeat(" ", 13)+"TAIL',COND=(8,LT)",
+		"//SYSTSIN  DD DUMMY",
+		fmt.Sprintf("%-71sX", "//OUT      DD DSN=PROD.REPORT.OUT,DISP=(,CATLG),UNIT=SYSDA  REPORT"),
+		"//             THIS CARD CONTINUES THE COMMENT FIELD ONLY",
+		"//S3       EXEC PGM=IEFBR14",
+	)
+}
+
+type ddLite struct {
+	DDName  string `json:"ddname"`
+	Dataset string `json:"dataset,omitempty"`
+	DISP    string `json:"disp,omitempty"`
+	Space   string `json:"space,omitempty"`
+	DCB     string `json:"dcb,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+type contStep struct {
+	Name       string   `json:"name"`
+	Program    string   `json:"program"`
+	Conditions string   `json:"conditions,omitempty"`
+	DD         []ddLite `json:"dd,omitempty"`
+}
+
+func TestGolden_ContinuationCards(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "cont.jcl"), []byte(sampleContinued()), 0o644); err != nil {
+		t.Fatalf("write sample: %v", err)
+	}
+	run, _ := parser.Parse(dir)
+	if len(run.Jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(run.Jobs))
+	}
+
+	got, err := json.MarshalIndent(liteSteps(run.Jobs[0]), "", "  ")
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(continuationGolden), 0o755); err != nil {
+			t.Fatalf("mkdir golden dir: %v", err)
+		}
+		if err := os.WriteFile(continuationGolden, got, 0o644); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(continuationGolden)
+	if err != nil {
+		t.Fatalf("read golden (%s): %v", continuationGolden, err)
+	}
+	if !bytes.Equal(bytes.TrimSpace(got), bytes.TrimSpace(want)) {
+		t.Fatalf("golden mismatch.\n  want: %s\n  got:  %s\nTip: update with\n  go test ./test/golden -run TestGolden_ContinuationCards -count=1 -args -update", want, got)
+	}
+}
+
+func liteSteps(j ir.Job) []contStep {
+	var out []contStep
+	for _, s := range j.Steps {
+		cs := contStep{Name: s.Name, Program: s.Program, Conditions: s.Conditions}
+		for _, dd := range s.DD {
+			cs.DD = append(cs.DD, ddLite{
+				DDName: dd.DDName, Dataset: dd.Dataset, DISP: dd.DISP,
+				Space: dd.Space, DCB: dd.DCB, Content: dd.Content,
+			})
+		}
+		out = append(out, cs)
+	}
+	return out
+}
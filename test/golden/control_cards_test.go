@@ -0,0 +1,119 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/parser"
+	"github.com/codewithboateng/jclift/internal/rules"
+)
+
+const controlCardsJob = `//CARDJOB  JOB (1),'CARDS',CLASS=A
+//SORTPAY  EXEC PGM=SORT
+//SORTIN   DD DSN=PROD.PAY,DISP=SHR
+//SORTOUT  DD DSN=PROD.PAY.SORTED,DISP=(NEW,CATLG),SPACE=(CYL,(5,1))
+//SYSIN    DD DSN=PROD.CNTL(SORTPAY),DISP=SHR
+//SORTCPY  EXEC PGM=SORT
+//SORTIN   DD DSN=PROD.PAY,DISP=SHR
+//SORTOUT  DD DSN=PROD.PAY.COPY,DISP=(NEW,CATLG),SPACE=(CYL,(5,1))
+//SYSIN    DD DSN=PROD.CNTL(COPY),DISP=SHR
+//         DD DSN=PROD.SORT.OPTIONS,DISP=SHR
+//UNKNOWN  EXEC PGM=SORT
+//SORTIN   DD DSN=PROD.PAY,DISP=SHR
+//SYSIN    DD DSN=OTHER.CNTL(SORTX),DISP=SHR
+//MISSING  EXEC PGM=SORT
+//SYSIN    DD DSN=PROD.CNTL(NOPE),DISP=SHR
+`
+
+func TestParser_ControlCardsFromLibrary(t *testing.T) {
+	dir, mirror := t.TempDir(), t.TempDir()
+	options := filepath.Join(t.TempDir(), "opts.txt")
+	files := map[string]string{
+		filepath.Join(dir, "cards.jcl"):   controlCardsJob,
+		filepath.Join(mirror, "SORTPAY"):  "  SORT FIELDS=(1,8,CH,A)\n",
+		filepath.Join(mirror, "COPY.txt"): "  SORT FIELDS=COPY\n",
+		options:                           "  OPTION VLSHRT\n",
+	}
+	for p, body := range files {
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run, diags := parser.ParseWithOptions(dir, parser.Options{SysinLibs: []parser.ProcLib{
+		{Dir: mirror, Dataset: "PROD.CNTL"},
+		{Dir: options, Dataset: "PROD.SORT.OPTIONS"}, // sequential dataset mirrored by a file
+	}})
+	if len(run.Jobs) != 1 || len(run.Jobs[0].Steps) != 4 {
+		t.Fatalf("unexpected shape: %+v", run.Jobs)
+	}
+	steps := run.Jobs[0].Steps
+
+	pay := findDD(steps[0], "SYSIN")
+	if pay.Content != "  SORT FIELDS=(1,8,CH,A)\n" || pay.ContentFile != filepath.Join(mirror, "SORTPAY") || pay.Instream != "" {
+		t.Errorf("SORTPAY SYSIN: %+v", pay)
+	}
+	cpy := findDD(steps[1], "SYSIN")
+	if cpy.Content != "  SORT FIELDS=COPY\n" || len(cpy.Concat) != 1 || cpy.Concat[0].Content != "  OPTION VLSHRT\n" {
+		t.Errorf("SORTCPY SYSIN: %+v", cpy)
+	}
+	if unk := findDD(steps[2], "SYSIN"); unk.Content != "" || unk.ContentFile != "" {
+		t.Errorf("unmapped dataset should not get content: %+v", unk)
+	}
+
+	missing := 0
+	for _, d := range diags.Items {
+		if d.Code == parser.CodeControlCardsMissing {
+			missing++
+			if d.Line != 15 {
+				t.Errorf("missing member reported on line %d", d.Line)
+			}
+		}
+	}
+	if missing != 1 {
+		t.Errorf("expected one %s diagnostic, got %v", parser.CodeControlCardsMissing, diags.Items)
+	}
+
+	// Only the FIELDS=COPY member is an identity sort: the keyed member and
+	// the unmapped / missing datasets are not guessed at.
+	var flagged []string
+	for _, f := range rules.Evaluate(&run) {
+		if f.RuleID == "SORT-IDENTITY" {
+			flagged = append(flagged, f.Step)
+		}
+	}
+	if len(flagged) != 1 || flagged[0] != "SORTCPY" {
+		t.Errorf("SORT-IDENTITY flagged %v, want [SORTCPY]", flagged)
+	}
+}
+
+// A mirrored member that decodes to nothing, such as an unload of an empty
+// PDS, is reported as such.
+func TestParser_ControlCardsEmptyMember(t *testing.T) {
+	dir, mirror := t.TempDir(), t.TempDir()
+	files := map[string][]byte{
+		filepath.Join(dir, "cards.jcl"): []byte(`//EMPTYJOB JOB (1),'CARDS',CLASS=A
+//SORTPAY  EXEC PGM=SORT
+//SYSIN    DD DSN=PROD.CNTL(EMPTY),DISP=SHR
+`),
+		filepath.Join(mirror, "EMPTY"): iebcopyXmit(nil, nil, nil),
+	}
+	for p, body := range files {
+		if err := os.WriteFile(p, body, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, diags := parser.ParseWithOptions(dir, parser.Options{SysinLibs: []parser.ProcLib{{Dir: mirror, Dataset: "PROD.CNTL"}}})
+	var msgs []string
+	for _, d := range diags.Items {
+		if d.Code == parser.CodeReadError {
+			msgs = append(msgs, d.Message)
+		}
+	}
+	if len(msgs) != 1 || !strings.HasSuffix(msgs[0], "EMPTY: no members") {
+		t.Errorf("read errors %q", msgs)
+	}
+}
@@ -0,0 +1,201 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/lineage"
+	"github.com/codewithboateng/jclift/internal/parser"
+	"github.com/codewithboateng/jclift/internal/rules"
+	"github.com/codewithboateng/jclift/internal/rulesdsl"
+	"github.com/codewithboateng/jclift/internal/storage"
+)
+
+var crossJobSources = map[string]string{
+	"loada.jcl": `//LOADA    JOB (1),'LOAD A',CLASS=A
+//S1       EXEC PGM=SORT
+//SORTIN   DD DSN=XJOB.EXTRACT.FILE,DISP=SHR
+//SORTOUT  DD DSN=XJOB.CUSTOMER.MASTER,DISP=OLD
+//DAILY    DD DSN=XJOB.PAY.DAILY(+1),DISP=(NEW,CATLG),SPACE=(CYL,(1,1))
+//SYSIN    DD *
+  SORT FIELDS=(1,8,CH,A)
+/*
+`,
+	"loadb.jcl": `//LOADB    JOB (1),'LOAD B',CLASS=A
+//S1       EXEC PGM=IEBGENER
+//SYSUT1   DD DSN=XJOB.EXTRACT.FILE,DISP=SHR
+//SYSUT2   DD DSN=XJOB.CUSTOMER.MASTER,DISP=(MOD,KEEP)
+//SYSIN    DD DUMMY
+//S2       EXEC PGM=IEFBR14
+//DAILY    DD DSN=XJOB.PAY.DAILY(+1),DISP=(NEW,CATLG),SPACE=(CYL,(1,1))
+//OUT      DD DSN=XJOB.SUMMARY,DISP=(NEW,CATLG),SPACE=(CYL,(1,1))
+`,
+	"loadc.jcl": `//LOADC    JOB (1),'LOAD C',CLASS=A
+//S1       EXEC PGM=PAYRPT
+//IN       DD DSN=XJOB.EXTRACT.FILE,DISP=SHR
+//OUT      DD DSN=XJOB.SUMMARY,DISP=(NEW,CATLG,DELETE),SPACE=(CYL,(1,1))
+`,
+}
+
+const crossJobPack = `rules:
+  - id: "DSL-XJOB-SHARED-EXTRACT"
+    summary: "Extract read by many jobs"
+    type: "COST"
+    severity: "LOW"
+    message: "Extract is read by several jobs."
+    scope: "run"
+    where:
+      dataset: "^XJOB\\.EXTRACT\\."
+      access: "read"
+      min_jobs: 3
+`
+
+func TestRules_CrossJob(t *testing.T) {
+	dir := t.TempDir()
+	for name, body := range crossJobSources {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := rules.Get("DSL-XJOB-SHARED-EXTRACT"); !ok {
+		pack := filepath.Join(t.TempDir(), "pack.yaml")
+		if err := os.WriteFile(pack, []byte(crossJobPack), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := rulesdsl.LoadAndRegister(pack); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if r, _ := rules.Get("XJOB-GDG-MULTI-WRITER"); r.Scope() != rules.ScopeRun {
+		t.Errorf("XJOB-GDG-MULTI-WRITER scope %q", r.Scope())
+	}
+
+	run, _ := parser.ParseWithOptions(dir, parser.Options{})
+	got := map[string]ir.Finding{}
+	for _, f := range rules.Evaluate(&run) {
+		if len(f.Jobs) > 0 {
+			got[f.RuleID] = f
+		}
+	}
+
+	want := map[string]struct {
+		jobs []string
+		step string
+		dd   string
+	}{
+		"XJOB-DISP-OLD-CONFLICT":  {[]string{"LOADA", "LOADB"}, "S1", "SORTOUT"},
+		"XJOB-GDG-MULTI-WRITER":   {[]string{"LOADA", "LOADB"}, "S1", "DAILY"},
+		"XJOB-DUPLICATE-CREATE":   {[]string{"LOADB", "LOADC"}, "S2", "OUT"},
+		"DSL-XJOB-SHARED-EXTRACT": {[]string{"LOADA", "LOADB", "LOADC"}, "S1", "SORTIN"},
+	}
+	for id, w := range want {
+		f, ok := got[id]
+		if !ok {
+			t.Errorf("%s: no cross-job finding", id)
+			continue
+		}
+		if !reflect.DeepEqual(f.Jobs, w.jobs) || f.Job != w.jobs[0] || f.Step != w.step || f.DD != w.dd {
+			t.Errorf("%s: job %s jobs %v step %s dd %s", id, f.Job, f.Jobs, f.Step, f.DD)
+		}
+		if f.Loc == nil || f.Loc.Line == 0 {
+			t.Errorf("%s: no location", id)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("unexpected cross-job findings: %v", got)
+	}
+
+	// A waiver naming any involved job waives the cross-job finding.
+	kept, waived := rules.ApplyWaivers([]ir.Finding{got["XJOB-DUPLICATE-CREATE"]},
+		[]storage.Waiver{{RuleID: "XJOB-DUPLICATE-CREATE", Job: "LOADC"}})
+	if len(kept) != 0 || len(waived) != 1 || waived[0].Reason != ir.SuppressedWaived {
+		t.Errorf("waiver on LOADC: kept %v", kept)
+	}
+}
+
+// Cross-job rules read the parsed DISP on the lineage edges: UNCATLG is
+// not CATLG, and an omitted status is NEW.
+func TestRules_CrossJobTypedDisposition(t *testing.T) {
+	dir := t.TempDir()
+	for name, body := range map[string]string{
+		"scra.jcl": `//SCRA     JOB (1),'SCRATCH A',CLASS=A
+//S1       EXEC PGM=IEFBR14
+//WORK     DD DSN=XJOB.SCRATCH,DISP=(NEW,UNCATLG),SPACE=(CYL,(1,1))
+//KEEP     DD DSN=XJOB.KEPT,DISP=(,CATLG),SPACE=(CYL,(1,1))
+`,
+		"scrb.jcl": `//SCRB     JOB (1),'SCRATCH B',CLASS=A
+//S1       EXEC PGM=IEFBR14
+//WORK     DD DSN=XJOB.SCRATCH,DISP=(NEW,UNCATLG),SPACE=(CYL,(1,1))
+//KEEP     DD DSN=XJOB.KEPT,DISP=(,CATLG,DELETE),SPACE=(CYL,(1,1))
+//LOG      DD DSN=XJOB.LOG,SPACE=(CYL,(1,1))
+`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run, _ := parser.ParseWithOptions(dir, parser.Options{})
+	g := lineage.Build(&run)
+
+	want := map[string]ir.Disposition{
+		"SCRA.WORK": {Status: "NEW", Normal: "UNCATLG"},
+		"SCRA.KEEP": {Status: "NEW", Normal: "CATLG"},
+		"SCRB.KEEP": {Status: "NEW", Normal: "CATLG", Abnormal: "DELETE"},
+		"SCRB.LOG":  {Status: "NEW"}, // DISP omitted
+	}
+	for _, e := range g.Edges {
+		if d, ok := want[e.Job+"."+e.DD]; ok && e.Disposition != d {
+			t.Errorf("%s.%s: disposition %+v, want %+v", e.Job, e.DD, e.Disposition, d)
+		}
+	}
+
+	var dup []string
+	for _, f := range rules.Evaluate(&run) {
+		if f.RuleID == "XJOB-DUPLICATE-CREATE" {
+			dup = append(dup, f.Evidence)
+		}
+	}
+	if len(dup) != 1 || !strings.HasPrefix(dup[0], "XJOB.KEPT:") {
+		t.Errorf("XJOB-DUPLICATE-CREATE findings %v, want only XJOB.KEPT", dup)
+	}
+}
+
+// DISP=OLD on an application DD takes the exclusive enqueue as much as on
+// SORTOUT or SYSUT2.
+func TestRules_CrossJobDispOldAnyDD(t *testing.T) {
+	dir := t.TempDir()
+	for name, body := range map[string]string{
+		"paya.jcl": `//PAYA     JOB (1),'PAY A',CLASS=A
+//S1       EXEC PGM=PAYUPD
+//PAYOUT   DD DSN=XJOB.PAY.LEDGER,DISP=OLD
+`,
+		"payb.jcl": `//PAYB     JOB (1),'PAY B',CLASS=A
+//S1       EXEC PGM=PAYFIX
+//LEDGER   DD DSN=XJOB.PAY.LEDGER,DISP=(OLD,KEEP)
+//RATES    DD DSN=XJOB.PAY.RATES,DISP=SHR
+`,
+		"payc.jcl": `//PAYC     JOB (1),'PAY C',CLASS=A
+//S1       EXEC PGM=PAYRPT
+//RATES    DD DSN=XJOB.PAY.RATES,DISP=SHR
+`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run, _ := parser.ParseWithOptions(dir, parser.Options{})
+
+	var got []ir.Finding
+	for _, f := range rules.Evaluate(&run) {
+		if f.RuleID == "XJOB-DISP-OLD-CONFLICT" {
+			got = append(got, f)
+		}
+	}
+	if len(got) != 1 || got[0].Dataset != "XJOB.PAY.LEDGER" || !reflect.DeepEqual(got[0].Jobs, []string{"PAYA", "PAYB"}) || got[0].DD != "PAYOUT" {
+		t.Errorf("DISP=OLD conflicts %+v", got)
+	}
+}
@@ -0,0 +1,103 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/parser"
+)
+
+const allocJob = `//ALLOC    JOB (1),'ALLOC',CLASS=A
+//S1       EXEC PGM=SORT
+//SORTIN   DD DSN=PROD.INPUT,DISP=SHR,UNIT=(SYSDA,2)
+//SORTOUT  DD DSN=PROD.OUTPUT,DISP=(,CATLG,DELETE),
+//            SPACE=(CYL,(50,10),RLSE),UNIT=SYSDA,
+//            DCB=(RECFM=FB,LRECL=80,BLKSIZE=27920)
+//SORTWK01 DD SPACE=(TRK,(300,30),,CONTIG)
+//PDS      DD DSN=PROD.LOAD,DISP=(NEW,CATLG),SPACE=(6160,(100,20,50)),
+//            RECFM=U,DSORG=PO,VOL=SER=VOL001
+//RECS     DD DSN=PROD.RECS,DISP=(NEW,KEEP),SPACE=(200,(10,5)),
+//            AVGREC=K,DATACLAS=DCFB,
+//            STORCLAS=SCFAST,MGMTCLAS=MCDAILY
+//MODEL    DD DSN=PROD.COPY,DISP=(NEW,CATLG),LIKE=PROD.OUTPUT,
+//            DCB=*.S1.SORTOUT,LRECL=133,VOL=(PRIVATE,,,,SER=(V1,V2))
+//SYSOUT   DD SYSOUT=*
+//REPORT   DD SYSOUT=(A,,STD1)
+`
+
+func TestParser_DDAllocation(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "alloc.jcl"), []byte(allocJob), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run, _ := parser.Parse(dir)
+	if len(run.Jobs) != 1 || len(run.Jobs[0].Steps) != 1 {
+		t.Fatalf("unexpected shape: %+v", run.Jobs)
+	}
+	st := run.Jobs[0].Steps[0]
+	get := func(name string) ir.DD {
+		dd := findDD(st, name)
+		if dd == nil {
+			t.Fatalf("DD %s not found", name)
+		}
+		return *dd
+	}
+
+	in := get("SORTIN")
+	if in.Status() != "SHR" || in.Creates() || in.Unit != "SYSDA" || in.Alloc != nil {
+		t.Errorf("SORTIN: %+v", in)
+	}
+
+	out := get("SORTOUT")
+	if !reflect.DeepEqual(out.Disposition, &ir.Disposition{Status: "NEW", Normal: "CATLG", Abnormal: "DELETE"}) {
+		t.Errorf("SORTOUT disposition: %+v", out.Disposition)
+	}
+	if !reflect.DeepEqual(out.Alloc, &ir.SpaceAlloc{Unit: "CYL", Primary: 50, Secondary: 10, Release: true}) {
+		t.Errorf("SORTOUT space: %+v", out.Alloc)
+	}
+	if !reflect.DeepEqual(out.Attrs, &ir.DCBAttrs{RECFM: "FB", LRECL: 80, BLKSIZE: 27920}) {
+		t.Errorf("SORTOUT dcb: %+v", out.Attrs)
+	}
+
+	wk := get("SORTWK01")
+	if wk.Disposition != nil || wk.Alloc == nil || wk.Alloc.Unit != "TRK" || wk.Alloc.Primary != 300 || !wk.Alloc.Contig {
+		t.Errorf("SORTWK01: %+v alloc=%+v", wk, wk.Alloc)
+	}
+
+	pds := get("PDS")
+	if pds.Alloc == nil || pds.Alloc.Unit != "BLK" || pds.Alloc.BlockLen != 6160 || pds.Alloc.Directory != 50 {
+		t.Errorf("PDS space: %+v", pds.Alloc)
+	}
+	if !reflect.DeepEqual(pds.Attrs, &ir.DCBAttrs{RECFM: "U", DSORG: "PO"}) {
+		t.Errorf("PDS dcb: %+v", pds.Attrs)
+	}
+	if pds.Vol == nil || !reflect.DeepEqual(pds.Vol.Serials, []string{"VOL001"}) {
+		t.Errorf("PDS vol: %+v", pds.Vol)
+	}
+
+	recs := get("RECS")
+	if recs.Alloc == nil || recs.Alloc.AvgRec != "K" || recs.Alloc.PrimaryMB(ir.Geometry{}) != 2000.0/1024 {
+		t.Errorf("RECS space: %+v", recs.Alloc)
+	}
+	if !recs.Keeps() || recs.DataClass != "DCFB" || recs.StorClass != "SCFAST" || recs.MgmtClass != "MCDAILY" {
+		t.Errorf("RECS: %+v", recs)
+	}
+
+	model := get("MODEL")
+	if model.Like != "PROD.OUTPUT" || model.Attrs == nil || model.Attrs.Model != "*.S1.SORTOUT" || model.Attrs.LRECL != 133 {
+		t.Errorf("MODEL: %+v attrs=%+v", model, model.Attrs)
+	}
+	if model.Vol == nil || !model.Vol.Private || !reflect.DeepEqual(model.Vol.Serials, []string{"V1", "V2"}) {
+		t.Errorf("MODEL vol: %+v", model.Vol)
+	}
+
+	if s := get("SYSOUT").Sysout; s != "*" {
+		t.Errorf("SYSOUT class: %q", s)
+	}
+	if s := get("REPORT").Sysout; s != "A" {
+		t.Errorf("REPORT class: %q", s)
+	}
+}
@@ -0,0 +1,164 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/cost"
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/parser"
+	"github.com/codewithboateng/jclift/internal/rules"
+)
+
+var deadOutputSources = map[string]string{
+	"extract.jcl": `//EXTRACT  JOB (1),'EXTRACT',CLASS=A
+//PULL     EXEC PGM=SORT
+//SORTIN   DD DSN=DEAD.PAY.MASTER,DISP=SHR
+//SORTOUT  DD DSN=&&PAYTMP,DISP=(NEW,PASS),SPACE=(CYL,(5,1))
+//AUDIT    DD DSN=DEAD.PAY.AUDIT,DISP=(NEW,CATLG),SPACE=(CYL,(5,1))
+//SYSIN    DD *
+  SORT FIELDS=(1,8,CH,A)
+/*
+//LOAD     EXEC PGM=IEBGENER
+//SYSUT1   DD DSN=&&PAYTMP,DISP=(OLD,DELETE)
+//SYSUT2   DD DSN=DEAD.PAY.DAILY(+1),DISP=(NEW,CATLG),SPACE=(CYL,(5,1))
+//SYSIN    DD DUMMY
+//SPARE    EXEC PGM=IEBGENER
+//SYSUT1   DD DSN=DEAD.PAY.MASTER,DISP=SHR
+//SYSUT2   DD DSN=&&UNUSED,DISP=(NEW,PASS),SPACE=(CYL,(5,1))
+//SYSIN    DD DUMMY
+`,
+	"report.jcl": `//REPORT   JOB (1),'REPORT',CLASS=A
+//PRINT    EXEC PGM=PAYRPT
+//INFILE   DD DSN=DEAD.PAY.DAILY(0),DISP=SHR
+//OUTFILE  DD DSN=DEAD.PAY.REPORT,DISP=(NEW,KEEP),SPACE=(CYL,(1,1))
+`,
+}
+
+func TestRules_DeadOutput(t *testing.T) {
+	dir := t.TempDir()
+	for name, body := range deadOutputSources {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run, _ := parser.ParseWithOptions(dir, parser.Options{})
+	cost.Annotate(&run, 1)
+
+	var got []ir.Finding
+	for _, f := range rules.Evaluate(&run) {
+		if f.RuleID == "DD-DEAD-OUTPUT" {
+			got = append(got, f)
+		}
+	}
+	// AUDIT is cataloged and never read; &&UNUSED is passed to no later
+	// step. &&PAYTMP feeds LOAD, DAILY(+1) feeds REPORT, and NEW,KEEP
+	// outputs are out of scope.
+	type key struct{ step, dd, evidence string }
+	var keys []key
+	for _, f := range got {
+		keys = append(keys, key{f.Job + "." + f.Step, f.DD, f.Evidence})
+	}
+	want := []key{
+		{"EXTRACT.PULL", "AUDIT", "AUDIT DSN=DEAD.PAY.AUDIT DISP=(NEW,CATLG)"},
+		{"EXTRACT.SPARE", "SYSUT2", "SYSUT2 DSN=&&UNUSED DISP=(NEW,PASS)"},
+	}
+	if !reflect.DeepEqual(keys, want) {
+		t.Fatalf("dead outputs:\n got %v\nwant %v", keys, want)
+	}
+	steps := run.Jobs[0].Steps
+	if got[0].SavingsMIPS != steps[0].Annotations.Cost.MIPS || got[0].SavingsMIPS == 0 {
+		t.Errorf("PULL savings %v, step cost %v", got[0].SavingsMIPS, steps[0].Annotations.Cost.MIPS)
+	}
+	if got[1].SavingsMIPS != steps[2].Annotations.Cost.MIPS {
+		t.Errorf("SPARE savings %v, step cost %v", got[1].SavingsMIPS, steps[2].Annotations.Cost.MIPS)
+	}
+}
+
+// Two members holding jobs of the same name are still two jobs.
+func TestRules_DeadOutputSameJobName(t *testing.T) {
+	dir := t.TempDir()
+	for name, body := range map[string]string{
+		"a.jcl": `//NIGHTLY  JOB (1),'WRITER',CLASS=A
+//MAKE     EXEC PGM=IEBGENER
+//SYSUT1   DD DSN=TWIN.IN,DISP=SHR
+//SYSUT2   DD DSN=TWIN.SHARED,DISP=(NEW,CATLG),SPACE=(CYL,(1,1))
+//SYSIN    DD DUMMY
+//PASS     EXEC PGM=IEBGENER
+//SYSUT1   DD DSN=TWIN.IN,DISP=SHR
+//SYSUT2   DD DSN=&&WORK,DISP=(NEW,PASS),SPACE=(CYL,(1,1))
+//SYSIN    DD DUMMY
+`,
+		"b.jcl": `//NIGHTLY  JOB (1),'READER',CLASS=A
+//USE      EXEC PGM=PAYRPT
+//INFILE   DD DSN=TWIN.SHARED,DISP=SHR
+//SKIP     EXEC PGM=IEFBR14
+//TAKE     EXEC PGM=PAYRPT
+//INFILE   DD DSN=&&WORK,DISP=(OLD,DELETE)
+`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run, _ := parser.ParseWithOptions(dir, parser.Options{})
+
+	var got []string
+	for _, f := range rules.Evaluate(&run) {
+		if f.RuleID == "DD-DEAD-OUTPUT" {
+			got = append(got, f.Job+"."+f.Step+"."+f.DD)
+		}
+	}
+	// The second NIGHTLY reads TWIN.SHARED at its first step; the first
+	// NIGHTLY's &&WORK is not passed to the other job's later step.
+	if want := []string{"NIGHTLY.PASS.SYSUT2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("dead outputs %v, want %v", got, want)
+	}
+}
+
+// A PDS member is read through its library and the other way round.
+func TestRules_DeadOutputPDSMembers(t *testing.T) {
+	dir := t.TempDir()
+	for name, body := range map[string]string{
+		"build.jcl": `//BUILD    JOB (1),'BUILD',CLASS=A
+//MEMBER   EXEC PGM=IEBGENER
+//SYSUT1   DD DSN=PDS.IN,DISP=SHR
+//SYSUT2   DD DSN=PDS.CARDS(DAILY),DISP=(NEW,CATLG),SPACE=(CYL,(1,1,5))
+//SYSIN    DD DUMMY
+//LIBRARY  EXEC PGM=IEBGENER
+//SYSUT1   DD DSN=PDS.IN,DISP=SHR
+//SYSUT2   DD DSN=PDS.PARMS,DISP=(NEW,CATLG),SPACE=(CYL,(1,1,5))
+//SYSIN    DD DUMMY
+//OTHER    EXEC PGM=IEBGENER
+//SYSUT1   DD DSN=PDS.IN,DISP=SHR
+//SYSUT2   DD DSN=PDS.SKEL(WEEKLY),DISP=(NEW,CATLG),SPACE=(CYL,(1,1,5))
+//SYSIN    DD DUMMY
+`,
+		"use.jcl": `//USE      JOB (1),'USE',CLASS=A
+//WHOLE    EXEC PGM=PAYRPT
+//LIB      DD DSN=PDS.CARDS,DISP=SHR
+//ONE      EXEC PGM=PAYRPT
+//PARM     DD DSN=PDS.PARMS(RUN),DISP=SHR
+//SKEL     DD DSN=PDS.SKEL(DAILY),DISP=SHR
+`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run, _ := parser.ParseWithOptions(dir, parser.Options{})
+
+	var got []string
+	for _, f := range rules.Evaluate(&run) {
+		if f.RuleID == "DD-DEAD-OUTPUT" {
+			got = append(got, f.Job+"."+f.Step)
+		}
+	}
+	// CARDS(DAILY) is read with the whole library and PARMS through a
+	// member; nobody reads SKEL(WEEKLY), only another member.
+	if want := []string{"BUILD.OTHER"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("dead outputs %v, want %v", got, want)
+	}
+}
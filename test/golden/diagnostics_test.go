@@ -0,0 +1,71 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/parser"
+)
+
+const brokenJob = `//BROKEN   JOB (1),'DIAG',CLASS=A,COLOUR=RED
+//S1       EXEC PGM=IEFBR14,PARM=(A,B
+//OUT      DD DSN=PROD.OUT,DISP=SHR,
+//S2       EXEC PGM=SORT
+//SORTIN   DD DSN=&MISSING..DATA,DISP=SHR,FLAVOR=X
+//TOOLONGNAME DD DUMMY
+//S3       EXCE PGM=IEFBR14
+//S4       EXEC MYPROC
+//         ENDIF
+//SYSIN    DD *
+  SORT FIELDS=COPY
+`
+
+func TestParser_Diagnostics(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "broken.jcl")
+	if err := os.WriteFile(file, []byte(brokenJob), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run, diags := parser.Parse(dir)
+
+	// Parsing recovers: every step is still present.
+	if len(run.Jobs) != 1 || len(run.Jobs[0].Steps) != 3 {
+		t.Fatalf("unexpected shape: %+v", run.Jobs)
+	}
+	if len(run.Diagnostics) != len(diags.Items) {
+		t.Errorf("run carries %d diagnostics, parser returned %d", len(run.Diagnostics), len(diags.Items))
+	}
+
+	want := []struct {
+		severity, code string
+		line           int
+	}{
+		{ir.SeverityWarning, parser.CodeUnknownKeyword, 1},  // COLOUR=
+		{ir.SeverityError, parser.CodeUnbalancedParens, 2},  // PARM=(A,B
+		{ir.SeverityError, parser.CodeMalformedCard, 4},     // OUT DD ends with a comma
+		{ir.SeverityWarning, parser.CodeSymbolUndefined, 5}, // &MISSING
+		{ir.SeverityWarning, parser.CodeUnknownKeyword, 5},  // FLAVOR=
+		{ir.SeverityError, parser.CodeMalformedCard, 6},     // TOOLONGNAME
+		{ir.SeverityError, parser.CodeMalformedCard, 7},     // EXCE
+		{ir.SeverityWarning, parser.CodeProcNotFound, 8},    // MYPROC
+		{ir.SeverityError, parser.CodeIfUnbalanced, 9},      // ENDIF without IF
+		{ir.SeverityWarning, parser.CodeInstreamOpen, 10},   // SYSIN DD * without /*
+	}
+	for _, w := range want {
+		found := false
+		for _, d := range diags.Items {
+			if d.Severity == w.severity && d.Code == w.code && d.Line == w.line && d.File == file {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("missing %s %s on line %d; got %v", w.severity, w.code, w.line, diags.Items)
+		}
+	}
+	if diags.Errors() != 5 {
+		t.Errorf("expected 5 errors, got %d: %v", diags.Errors(), diags.Items)
+	}
+}
@@ -0,0 +1,73 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/parser"
+)
+
+func TestParser_SourceDiscovery(t *testing.T) {
+	root, other := t.TempDir(), t.TempDir()
+	files := map[string]string{
+		// Extensionless members with PROC and SYSIN libraries side by side.
+		filepath.Join(root, "jobs", "PAYROLL"):        "//PAYROLL  JOB (1),'PAY',CLASS=A\n//S1       EXEC RUNPROC\n",
+		filepath.Join(root, "jobs", "BILLING"):        "//BILLING  JOB (1),'BILL',CLASS=A\n//S1       EXEC PGM=IEFBR14\n",
+		filepath.Join(root, "jobs", "README.md"):      "not JCL\n",
+		filepath.Join(root, "jobs", "old", "PAYROLL"): "//OLDPAY   JOB (1),'OLD',CLASS=A\n//S1       EXEC PGM=IEFBR14\n",
+		filepath.Join(root, "procs", "RUNPROC"):       "//RUNPROC  PROC\n//RUN      EXEC PGM=IEFBR14\n",
+		filepath.Join(root, "sysin", "CARDS"):         "  SORT FIELDS=COPY\n",
+		filepath.Join(other, "BILLING.jcl"):           "//BILLING2 JOB (1),'BILL',CLASS=A\n//S1       EXEC PGM=SORT\n",
+	}
+	for p, body := range files {
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	alias := filepath.Join(root, "jobs", "PAYTEST") // sorts after PAYROLL: the first path reached wins
+	if err := os.Symlink(filepath.Join(root, "jobs", "PAYROLL"), alias); err != nil {
+		t.Skip("symlinks unavailable:", err)
+	}
+
+	run, diags := parser.ParseSources([]string{root, other}, parser.Options{
+		ProcLibs:  []parser.ProcLib{{Dir: filepath.Join(root, "procs")}},
+		SysinLibs: []parser.ProcLib{{Dir: filepath.Join(root, "sysin")}},
+		Include:   []string{"*"},
+		Exclude:   []string{"*.md", "**/old/**"},
+	})
+
+	var names []string
+	for _, j := range run.Jobs {
+		names = append(names, j.Name)
+	}
+	sort.Strings(names)
+	if want := []string{"BILLING", "BILLING2", "PAYROLL"}; len(names) != len(want) ||
+		names[0] != want[0] || names[1] != want[1] || names[2] != want[2] {
+		t.Fatalf("jobs %v, want %v (diagnostics %v)", names, want, diags.Items)
+	}
+	if run.Source != root+", "+other {
+		t.Errorf("run source %q", run.Source)
+	}
+
+	want := map[string]string{
+		alias:                               parser.CodeDuplicateInput,  // symlink to PAYROLL
+		filepath.Join(other, "BILLING.jcl"): parser.CodeDuplicateMember, // BILLING in both sources
+	}
+	for file, code := range want {
+		found := false
+		for _, d := range diags.Items {
+			found = found || d.File == file && d.Code == code
+		}
+		if !found {
+			t.Errorf("missing %s for %s; got %v", code, file, diags.Items)
+		}
+	}
+	if len(diags.Items) != len(want) {
+		t.Errorf("unexpected diagnostics: %v", diags.Items)
+	}
+}
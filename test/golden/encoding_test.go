@@ -0,0 +1,204 @@
+package golden
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/parser"
+)
+
+// toEBCDIC encodes the characters used by the fixtures (same in 037 and 1047).
+func toEBCDIC(s string) []byte {
+	out := make([]byte, len(s))
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= 'A' && c <= 'I':
+			out[i] = 0xC1 + c - 'A'
+		case c >= 'J' && c <= 'R':
+			out[i] = 0xD1 + c - 'J'
+		case c >= 'S' && c <= 'Z':
+			out[i] = 0xE2 + c - 'S'
+		case c >= '0' && c <= '9':
+			out[i] = 0xF0 + c - '0'
+		default:
+			out[i] = map[byte]byte{' ': 0x40, '/': 0x61, '*': 0x5C, '=': 0x7E, ',': 0x6B,
+				'.': 0x4B, '(': 0x4D, ')': 0x5D, '\'': 0x7D}[c]
+		}
+	}
+	return out
+}
+
+// fb80 encodes lines as blank-padded 80-byte EBCDIC records.
+func fb80(lines ...string) []byte {
+	var b []byte
+	for _, l := range lines {
+		b = append(b, toEBCDIC(l+strings.Repeat(" ", 80-len(l)))...)
+	}
+	return b
+}
+
+// netdata wraps logical records into TRANSMIT segments, padded to FB-80.
+func netdata(records []struct {
+	control bool
+	data    []byte
+}) []byte {
+	var b []byte
+	for _, r := range records {
+		data := r.data
+		first := true
+		for {
+			n := min(len(data), 253)
+			flags := byte(0)
+			if first {
+				flags |= 0x80
+			}
+			if n == len(data) {
+				flags |= 0x40
+			}
+			if r.control {
+				flags |= 0x20
+			}
+			b = append(append(b, byte(n+2), flags), data[:n]...)
+			data, first = data[n:], false
+			if len(data) == 0 {
+				break
+			}
+		}
+	}
+	for len(b)%80 != 0 {
+		b = append(b, 0x40)
+	}
+	return b
+}
+
+// iebcopyXmit builds a TRANSMIT archive of an FB-80 PDS unloaded by IEBCOPY.
+// Directory entries come in names order; aliases maps an alias to the
+// member it points at.
+func iebcopyXmit(members map[string][]string, names []string, aliases map[string]string) []byte {
+	type rec = struct {
+		control bool
+		data    []byte
+	}
+	be16 := func(n int) []byte { return binary.BigEndian.AppendUint16(nil, uint16(n)) }
+
+	inmr02 := append(toEBCDIC("INMR02"), 0, 0, 0, 1)
+	inmr02 = append(inmr02, 0x10, 0x28, 0, 1)
+	inmr02 = append(append(inmr02, be16(7)...), toEBCDIC("IEBCOPY")...)
+	records := []rec{
+		{true, toEBCDIC("INMR01")},
+		{true, inmr02},
+		{true, toEBCDIC("INMR03")},
+	}
+
+	copyr1 := make([]byte, 56)
+	copy(copyr1[1:], []byte{0xCA, 0x6D, 0x0F})
+	copy(copyr1[8:], be16(80))
+	copyr1[10] = 0x90 // FB
+	records = append(records, rec{false, copyr1}, rec{false, make([]byte, 276)})
+
+	var entries, data []byte
+	ttr := map[string]byte{}
+	for _, name := range names {
+		if _, ok := aliases[name]; !ok {
+			ttr[name] = byte(len(ttr) + 1)
+		}
+	}
+	for _, name := range names {
+		if primary, ok := aliases[name]; ok {
+			entries = append(append(entries, toEBCDIC(name+strings.Repeat(" ", 8-len(name)))...), 0, 0, ttr[primary], 0x80)
+			continue
+		}
+		entries = append(append(entries, toEBCDIC(name+strings.Repeat(" ", 8-len(name)))...), 0, 0, ttr[name], 0)
+		block := fb80(members[name]...)
+		data = append(append(append(data, make([]byte, 10)...), be16(len(block))...), block...)
+		data = append(data, make([]byte, 12)...) // end of member
+	}
+	entries = append(entries, bytes.Repeat([]byte{0xFF}, 8)...)
+	dir := make([]byte, 276)
+	copy(dir[20:], be16(len(entries)+2))
+	copy(dir[22:], entries)
+	records = append(records, rec{false, dir}, rec{false, data}, rec{true, toEBCDIC("INMR06")})
+	return netdata(records)
+}
+
+func TestParser_BinaryInputs(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, data []byte) {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// FB-80 EBCDIC transfer without record separators.
+	write("fb80.bin", fb80(
+		"//FBJOB    JOB (1),'FB80',CLASS=A",
+		"//S1       EXEC PGM=IEFBR14",
+		"//OUT      DD DSN=PROD.FB80.OUT,DISP=SHR",
+	))
+	// XMIT of a PDS with two members; JOBB has no JOB card, and its alias
+	// ALIASB (listed first, as the directory is in name order) is no job
+	// of its own.
+	write("lib.xmi", iebcopyXmit(map[string][]string{
+		"JOBA": {"//JOBA     JOB (1),'XMIT',CLASS=A", "//S1       EXEC PGM=SORT", "//SYSIN    DD *", "  SORT FIELDS=COPY", "/*"},
+		"JOBB": {"//S1       EXEC PGM=IDCAMS"},
+	}, []string{"ALIASB", "JOBA", "JOBB"}, map[string]string{"ALIASB": "JOBB"}))
+
+	run, diags := parser.ParseWithOptions(dir, parser.Options{Encoding: parser.EncodingIBM1047})
+	if len(diags.Items) != 0 {
+		t.Errorf("unexpected diagnostics: %v", diags.Items)
+	}
+	jobs := map[string]int{}
+	for i, j := range run.Jobs {
+		jobs[j.Name] = i
+	}
+	if len(run.Jobs) != 3 {
+		t.Fatalf("expected 3 jobs, got %+v", run.Jobs)
+	}
+
+	fb := run.Jobs[jobs["FBJOB"]]
+	if dd := findDD(fb.Steps[0], "OUT"); dd == nil || dd.Dataset != "PROD.FB80.OUT" {
+		t.Errorf("FBJOB OUT: %+v", fb.Steps[0].DD)
+	}
+	if fb.Loc == nil || fb.Loc.Line != 1 || fb.Loc.EndLine != 3 {
+		t.Errorf("FBJOB location: %v", fb.Loc)
+	}
+
+	a := run.Jobs[jobs["JOBA"]]
+	if a.Steps[0].Program != "SORT" || findDD(a.Steps[0], "SYSIN").Content != "  SORT FIELDS=COPY\n" {
+		t.Errorf("JOBA: %+v", a.Steps)
+	}
+	if want := filepath.Join(dir, "lib.xmi") + "(JOBA)"; a.Loc == nil || a.Loc.File != want {
+		t.Errorf("JOBA location %v, want file %s", a.Loc, want)
+	}
+	b, ok := jobs["JOBB"]
+	if !ok || run.Jobs[b].Steps[0].Program != "IDCAMS" {
+		t.Errorf("JOBB (named after its member) missing: %+v", run.Jobs)
+	}
+
+	if parser.ValidEncoding("ebcdic-utf9") {
+		t.Errorf("unknown encodings must be rejected")
+	}
+}
+
+func TestParser_EBCDICTextKeepsEmptyRecords(t *testing.T) {
+	dir := t.TempDir()
+	// NL-separated EBCDIC text with an empty record before the EXEC.
+	var data []byte
+	for _, l := range []string{"//NLJOB    JOB (1),'NL',CLASS=A", "", "//S1       EXEC PGM=IEFBR14"} {
+		data = append(append(data, toEBCDIC(l)...), 0x15)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "nl.txt"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	run, _ := parser.ParseWithOptions(dir, parser.Options{Encoding: parser.EncodingIBM1047})
+	if len(run.Jobs) != 1 || len(run.Jobs[0].Steps) != 1 {
+		t.Fatalf("expected one job with one step, got %+v", run.Jobs)
+	}
+	if loc := run.Jobs[0].Steps[0].Loc; loc == nil || loc.Line != 3 {
+		t.Errorf("S1 location %v, want line 3", loc)
+	}
+}
@@ -0,0 +1,74 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/parser"
+)
+
+const execParamsJob = `//EXECJOB  JOB (1),'EXEC',CLASS=A
+//JOBLIB   DD DSN=PROD.LOADLIB,DISP=SHR
+//         DD DSN=SYS1.LINKLIB,DISP=SHR
+//SORT1    EXEC PGM=SORT,PARM='ABEND,MSGDDN=SYSOUT,DYNALLOC=(SYSDA,4)',
+//            REGION=0M,TIME=(1,30),DYNAMNBR=20,ACCT=(D123,X)
+//SORTIN   DD DSN=PROD.IN,DISP=SHR
+//TSO      EXEC PGM=IKJEFT01,PARM='%MYEXEC ARG1 ''TWO WORDS''',
+//            REGION=4096K,TIME=NOLIMIT,ADDRSPC=REAL
+//STEPLIB  DD DSN=TEST.LOADLIB,DISP=SHR
+//         DD DSN=PROD.LOADLIB,DISP=SHR
+//SYSTSPRT DD SYSOUT=*
+//APP      EXEC PGM=MYPROG,PARM=(A,'B C'),PARMDD=MYPARMS,REGION=64M
+`
+
+func TestParser_ExecParameters(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "exec.jcl"), []byte(execParamsJob), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run, diags := parser.Parse(dir)
+	if len(diags.Items) != 0 {
+		t.Errorf("unexpected diagnostics: %v", diags.Items)
+	}
+	if len(run.Jobs) != 1 || len(run.Jobs[0].Steps) != 3 {
+		t.Fatalf("unexpected shape: %+v", run.Jobs)
+	}
+	job := &run.Jobs[0]
+	if !reflect.DeepEqual(job.JobLib, []string{"PROD.LOADLIB", "SYS1.LINKLIB"}) {
+		t.Errorf("JOBLIB: %v", job.JobLib)
+	}
+
+	sort := job.Steps[0]
+	if sort.Parm != "ABEND,MSGDDN=SYSOUT,DYNALLOC=(SYSDA,4)" ||
+		!reflect.DeepEqual(sort.ParmArgs, []string{"ABEND", "MSGDDN=SYSOUT", "DYNALLOC=(SYSDA,4)"}) {
+		t.Errorf("SORT1 PARM: %q %q", sort.Parm, sort.ParmArgs)
+	}
+	if sort.Region != "0M" || sort.RegionKB != -1 || sort.Time != "(1,30)" || sort.TimeSeconds != 90 ||
+		sort.DynamNbr != 20 || sort.Acct != "(D123,X)" {
+		t.Errorf("SORT1 limits: %+v", sort)
+	}
+	if libs := sort.LoadLibraries(job); !reflect.DeepEqual(libs, job.JobLib) {
+		t.Errorf("SORT1 load libraries: %v", libs)
+	}
+
+	tso := job.Steps[1]
+	if !reflect.DeepEqual(tso.ParmArgs, []string{"%MYEXEC", "ARG1", "'TWO WORDS'"}) {
+		t.Errorf("TSO PARM args: %q", tso.ParmArgs)
+	}
+	if tso.RegionKB != 4096 || tso.TimeSeconds != -1 || tso.AddrSpc != "REAL" {
+		t.Errorf("TSO limits: %+v", tso)
+	}
+	if !reflect.DeepEqual(tso.StepLib, []string{"TEST.LOADLIB", "PROD.LOADLIB"}) || findDD(tso, "STEPLIB") != nil {
+		t.Errorf("TSO STEPLIB: %v (DDs %+v)", tso.StepLib, tso.DD)
+	}
+	if libs := tso.LoadLibraries(job); libs[0] != "TEST.LOADLIB" {
+		t.Errorf("TSO load libraries: %v", libs)
+	}
+
+	app := job.Steps[2]
+	if app.Parm != "A,B C" || app.ParmArgs != nil || app.ParmDD != "MYPARMS" || app.RegionKB != 64*1024 {
+		t.Errorf("APP: %+v", app)
+	}
+}
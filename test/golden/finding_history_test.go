@@ -0,0 +1,224 @@
+package golden
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codewithboateng/jclift/internal/api"
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/parser"
+	"github.com/codewithboateng/jclift/internal/reporting"
+	"github.com/codewithboateng/jclift/internal/rules"
+	"github.com/codewithboateng/jclift/internal/storage"
+)
+
+const historyJob = `//HISTJOB  JOB (1),'HISTORY',CLASS=A
+//S1       EXEC PGM=IEBGENER
+//SYSUT1   DD DSN=HIST.IN,DISP=SHR
+//SYSUT2   DD DSN=HIST.OUT,DISP=OLD
+//SYSIN    DD DUMMY
+`
+
+// The DISP=OLD on SYSUT2 is fixed; the IEBGENER copy stays.
+const historyJobFixed = `//HISTJOB  JOB (1),'HISTORY',CLASS=A
+//S1       EXEC PGM=IEBGENER
+//SYSUT1   DD DSN=HIST.IN,DISP=SHR
+//SYSUT2   DD DSN=HIST.OUT,DISP=SHR
+//SYSIN    DD DUMMY
+`
+
+func TestStorage_FindingHistory(t *testing.T) {
+	db, err := storage.OpenSQLite(filepath.Join(t.TempDir(), "jclift.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.CreateSchema(); err != nil {
+		t.Fatal(err)
+	}
+
+	src := t.TempDir()
+	t0 := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	save := func(id, jcl string, at time.Time) ir.Run {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(src, "hist.jcl"), []byte(jcl), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		run, _ := parser.Parse(src)
+		run.ID, run.StartedAt, run.Source = id, at, src
+		run.Findings = rules.Evaluate(&run)
+		if err := db.SaveRun(&run); err != nil {
+			t.Fatal(err)
+		}
+		return run
+	}
+	fingerprint := func(run ir.Run, ruleID string) string {
+		for _, f := range run.Findings {
+			if f.RuleID == ruleID {
+				return f.Fingerprint
+			}
+		}
+		t.Fatalf("%s: no %s finding", run.ID, ruleID)
+		return ""
+	}
+
+	r1 := save("run_h1", historyJob, t0)
+	disp, cp := fingerprint(r1, "DD-DISP-OLD-SERIALIZATION"), fingerprint(r1, "IEBGENER-REDUNDANT-COPY")
+	save("run_h1", historyJob, t0) // saving again counts nothing twice
+	save("run_h2", historyJobFixed, t0.Add(48*time.Hour))
+	r3 := save("run_h3", historyJob, t0.Add(72*time.Hour))
+
+	h, err := db.LoadFindingHistory(src, cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.FirstSeenRun != "run_h1" || h.LastSeenRun != "run_h3" || h.Occurrences != 3 ||
+		h.ResolvedAt != nil || h.Regressions != 0 || h.Status() != ir.FindingOpen {
+		t.Errorf("open finding: %+v", h)
+	}
+	if h.Age(r3.StartedAt) != 72*time.Hour {
+		t.Errorf("age %v", h.Age(r3.StartedAt))
+	}
+
+	h, err = db.LoadFindingHistory(src, disp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Occurrences != 2 || h.Regressions != 1 || h.RegressedRun != "run_h3" ||
+		h.ResolvedAt != nil || h.Status() != ir.FindingRegressed {
+		t.Errorf("regressed finding: %+v", h)
+	}
+	occ, err := db.ListFindingOccurrences(src, disp)
+	if err != nil || len(occ) != 2 || occ[0].RunID != "run_h1" || occ[1].RunID != "run_h3" {
+		t.Errorf("occurrences %+v (%v)", occ, err)
+	}
+
+	// The HTML report shows the age columns.
+	r3.History, err = db.LoadRunHistory(r3.ID)
+	if err != nil || len(r3.History) != len(r3.Findings) {
+		t.Fatalf("run history %v (%v)", r3.History, err)
+	}
+	path, err := reporting.WriteHTML(r3.ID, t.TempDir(), &r3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, _ := os.ReadFile(path)
+	for _, want := range []string{"<th>First Seen</th><th>Age</th>", "<td>regressed</td><td>run_h1</td><td>3d</td><td>2</td>", "Regressed: 1"} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("report lacks %q", want)
+		}
+	}
+
+	// Fixed for good in a fourth run.
+	save("run_h4", historyJobFixed, t0.Add(96*time.Hour))
+	if h, _ := db.LoadFindingHistory(src, disp); h.ResolvedRun != "run_h4" || h.Status() != ir.FindingResolved {
+		t.Errorf("resolved finding: %+v", h)
+	}
+
+	// Served by the API, 404 for unknown fingerprints.
+	srv := httptest.NewServer((&api.Server{DB: db}).Routes())
+	defer srv.Close()
+	resp, err := http.Get(srv.URL + "/api/v1/findings/" + disp + "/history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body struct {
+		History     ir.FindingHistory           `json:"history"`
+		Status      string                      `json:"status"`
+		Occurrences []storage.FindingOccurrence `json:"occurrences"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	resp.Body.Close()
+	if err != nil || body.Status != ir.FindingResolved || body.History.Regressions != 1 || len(body.Occurrences) != 2 {
+		t.Errorf("history endpoint: %+v (%v)", body, err)
+	}
+	resp, err = http.Get(srv.URL + "/api/v1/findings/nope/history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown fingerprint: status %d", resp.StatusCode)
+	}
+}
+
+// The same finding reported from two sources has a history in each: a run
+// of one source neither resolves nor regresses it in the other.
+func TestStorage_FindingHistoryPerSource(t *testing.T) {
+	db, err := storage.OpenSQLite(filepath.Join(t.TempDir(), "jclift.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.CreateSchema(); err != nil {
+		t.Fatal(err)
+	}
+
+	srcA, srcB := t.TempDir(), t.TempDir()
+	for _, dir := range []string{srcA, srcB} {
+		if err := os.WriteFile(filepath.Join(dir, "hist.jcl"), []byte(historyJob), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t0 := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	var disp string
+	for i, src := range []string{srcA, srcB, srcA, srcB} {
+		run, _ := parser.Parse(src)
+		run.ID, run.StartedAt, run.Source = "run_s"+string(rune('1'+i)), t0.Add(time.Duration(i)*time.Hour), src
+		run.Findings = rules.Evaluate(&run)
+		if err := db.SaveRun(&run); err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range run.Findings {
+			if f.RuleID == "DD-DISP-OLD-SERIALIZATION" {
+				disp = f.Fingerprint
+			}
+		}
+	}
+
+	for src, want := range map[string][2]string{srcA: {"run_s1", "run_s3"}, srcB: {"run_s2", "run_s4"}} {
+		h, err := db.LoadFindingHistory(src, disp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h.Source != src || h.FirstSeenRun != want[0] || h.LastSeenRun != want[1] || h.Occurrences != 2 ||
+			h.ResolvedAt != nil || h.Regressions != 0 || h.Status() != ir.FindingOpen {
+			t.Errorf("%s: %+v", src, h)
+		}
+		occ, err := db.ListFindingOccurrences(src, disp)
+		if err != nil || len(occ) != 2 || occ[0].RunID != want[0] || occ[1].RunID != want[1] {
+			t.Errorf("%s occurrences %+v (%v)", src, occ, err)
+		}
+	}
+	if hs, err := db.ListFindingHistory(disp); err != nil || len(hs) != 2 || hs[0].Source != srcB {
+		t.Errorf("histories %+v (%v)", hs, err)
+	}
+	if h, err := db.LoadRunHistory("run_s3"); err != nil || h[disp].Source != srcA {
+		t.Errorf("run history %+v (%v)", h, err)
+	}
+
+	// The API serves the source asked for, else the one seen last.
+	srv := httptest.NewServer((&api.Server{DB: db}).Routes())
+	defer srv.Close()
+	for query, want := range map[string]string{"": srcB, "?source=" + srcA: srcA} {
+		resp, err := http.Get(srv.URL + "/api/v1/findings/" + disp + "/history" + query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var body struct {
+			History     ir.FindingHistory           `json:"history"`
+			Occurrences []storage.FindingOccurrence `json:"occurrences"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		resp.Body.Close()
+		if err != nil || body.History.Source != want || len(body.Occurrences) != 2 {
+			t.Errorf("history%s: %+v (%v)", query, body, err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package golden
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/parser"
+	"github.com/codewithboateng/jclift/internal/reporting"
+	"github.com/codewithboateng/jclift/internal/rules"
+	"github.com/codewithboateng/jclift/internal/storage"
+)
+
+const fingerprintJob = `//FPJOB    JOB (1),'PRINTS',CLASS=A
+//S1       EXEC PGM=IEBGENER
+//SYSUT1   DD DSN=PROD.A,DISP=OLD
+//SYSUT2   DD DSN=PROD.B,DISP=OLD
+//DUP1     DD DSN=PROD.A,DISP=SHR
+//DUP2     DD DSN=PROD.B,DISP=SHR
+//SYSIN    DD DUMMY
+`
+
+// Same job after an edit: comment cards shift every line and the DDs are
+// listed in another order.
+const fingerprintJobEdited = `//FPJOB    JOB (1),'PRINTS',CLASS=A
+//* moved to the new schedule
+//*
+//S1       EXEC PGM=IEBGENER
+//DUP2     DD DSN=PROD.B,DISP=SHR
+//SYSUT1   DD DSN=PROD.A,DISP=OLD
+//DUP1     DD DSN=PROD.A,DISP=SHR
+//SYSUT2   DD DSN=PROD.B,DISP=OLD
+//SYSIN    DD DUMMY
+`
+
+func evaluateJCL(t *testing.T, id, jcl string) ir.Run {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "fp.jcl"), []byte(jcl), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run, _ := parser.Parse(dir)
+	run.ID = id
+	run.Findings = rules.Evaluate(&run)
+	return run
+}
+
+func TestRules_StableFingerprints(t *testing.T) {
+	// Structure only: reworded evidence keeps the fingerprint, another
+	// dataset on the same DD does not.
+	f := ir.Finding{RuleID: "DD-DISP-OLD-SERIALIZATION", Job: "FPJOB", Step: "S1", DD: "SYSUT1", Dataset: "PROD.A", Evidence: "SYSUT1 DISP=OLD"}
+	reworded, moved := f, f
+	reworded.Evidence, reworded.Message = "SYSUT1 DSN=PROD.A DISP=(OLD,KEEP)", "reworded"
+	moved.Dataset = "PROD.C"
+	if ir.FingerprintOf(f) != ir.FingerprintOf(reworded) || ir.FingerprintOf(f) == ir.FingerprintOf(moved) {
+		t.Errorf("fingerprints %s, reworded %s, other dataset %s", ir.FingerprintOf(f), ir.FingerprintOf(reworded), ir.FingerprintOf(moved))
+	}
+
+	base := evaluateJCL(t, "run-base", fingerprintJob)
+	head := evaluateJCL(t, "run-head", fingerprintJobEdited)
+
+	prints := func(run ir.Run) map[string]string {
+		out := map[string]string{}
+		for _, f := range run.Findings {
+			if f.Fingerprint == "" {
+				t.Fatalf("%s: no fingerprint", f.RuleID)
+			}
+			out[f.RuleID+"/"+f.DD] = f.Fingerprint
+		}
+		return out
+	}
+	bp, hp := prints(base), prints(head)
+	if len(bp) == 0 || len(bp) != len(hp) {
+		t.Fatalf("findings: base %v head %v", bp, hp)
+	}
+	for k, fp := range bp {
+		if hp[k] != fp {
+			t.Errorf("%s: fingerprint %s became %s after the edit", k, fp, hp[k])
+		}
+	}
+
+	// The diff sees no new or removed findings.
+	out := t.TempDir()
+	path, err := reporting.WriteDiffJSON(base.ID, head.ID, out, &base, &head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var diff struct {
+		Summary struct{ New, Removed int }
+	}
+	b, _ := os.ReadFile(path)
+	if err := json.Unmarshal(b, &diff); err != nil || diff.Summary.New != 0 || diff.Summary.Removed != 0 {
+		t.Errorf("diff summary %+v (%v)", diff.Summary, err)
+	}
+
+	// Fingerprints are stored with the findings, and a waiver on one
+	// fingerprint waives only that finding.
+	db, err := storage.OpenSQLite(filepath.Join(t.TempDir(), "jclift.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.CreateSchema(); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveRun(&head); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := db.ListFindings(head.ID, "LOW")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range stored {
+		if hp[f.RuleID+"/"+f.DD] != f.Fingerprint {
+			t.Errorf("stored %s/%s fingerprint %q", f.RuleID, f.DD, f.Fingerprint)
+		}
+		if f.DD == "SYSUT1" && f.Dataset != "PROD.A" {
+			t.Errorf("stored %s/%s dataset %q", f.RuleID, f.DD, f.Dataset)
+		}
+	}
+	target := hp["DD-DISP-OLD-SERIALIZATION/SYSUT1"]
+	kept, waived := rules.ApplyWaivers(head.Findings, []storage.Waiver{{RuleID: "DD-DISP-OLD-SERIALIZATION", Fingerprint: target}})
+	if len(waived) != 1 || waived[0].Fingerprint != target || len(kept) != len(head.Findings)-1 {
+		t.Errorf("fingerprint waiver: waived %+v", waived)
+	}
+}
@@ -0,0 +1,115 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/fix"
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/parser"
+	"github.com/codewithboateng/jclift/internal/rules"
+)
+
+// Cards carry sequence numbers in columns 73-80.
+var fixJob = strings.Join([]string{
+	"//FIXJOB   JOB (1),'FIX',CLASS=A                                        00010000",
+	"//COPY     EXEC PGM=SORT                                                00020000",
+	"//SORTIN   DD DSN=FIX.IN,DISP=SHR                                       00030000",
+	"//SORTOUT  DD DSN=FIX.COPY,DISP=(NEW,CATLG),SPACE=(CYL,(1,1))           00040000",
+	"//SYSIN    DD *                                                         00050000",
+	"  SORT FIELDS=COPY",
+	"/*",
+	"//GEN      EXEC PGM=IEBGENER      COPY THE EXTRACT                      00080000",
+	"//SYSUT1   DD DSN=FIX.IN,DISP=SHR                                       00090000",
+	"//SYSUT2   DD DSN=&&TMP,DISP=(NEW,CATLG,CATLG),                         00100000",
+	"//            UNIT=SYSDA       TEMP WORK FILE                           00110000",
+	"//SYSIN    DD DUMMY                                                     00120000",
+	"//OUT      DD DSN=FIX.NEW.OUTPUT.WITH.LONG.NAME,DISP=(NEW,CATLG)        00130000",
+	"//USE      EXEC PGM=PAYRPT                                              00140000",
+	"//IN       DD DSN=&&TMP,DISP=(OLD,DELETE)                               00150000",
+}, "\n") + "\n"
+
+func evaluateDir(t *testing.T, dir string) []ir.Finding {
+	t.Helper()
+	run, _ := parser.Parse(dir)
+	return rules.Evaluate(&run)
+}
+
+func TestFix_PlanDiffApply(t *testing.T) {
+	dir := t.TempDir()
+	member := filepath.Join(dir, "fix.jcl")
+	if err := os.WriteFile(member, []byte(fixJob), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	findings := evaluateDir(t, dir)
+
+	fixable := map[string]bool{}
+	for _, f := range findings {
+		if f.Fix != nil {
+			fixable[f.RuleID] = true
+		}
+	}
+	for _, id := range []string{"SORT-IDENTITY", "DD-NEW-MISSING-SPACE", "DD-TEMP-DATASET-KEEP", "IEBGENER-REDUNDANT-COPY"} {
+		if !fixable[id] {
+			t.Errorf("%s: no fix", id)
+		}
+	}
+
+	changes, skipped := fix.Plan(findings)
+	if len(changes) != 1 || len(skipped) != 0 {
+		t.Fatalf("changes %d skipped %+v", len(changes), skipped)
+	}
+	var diff strings.Builder
+	if err := fix.WriteDiff(&diff, changes); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"--- " + member + "\n+++ " + member + "\n@@ -1,15 +1,10 @@\n",
+		"-//COPY     EXEC PGM=SORT ",
+		"-  SORT FIELDS=COPY\n-/*\n",
+		// The comment keeps its column; sequence numbers stay in 73-80.
+		"+//GEN      EXEC PGM=ICEGENER      COPY THE EXTRACT                      00080000\n",
+		"+//SYSUT2   DD DSN=&&TMP,DISP=(NEW,PASS,DELETE),                         00100000\n",
+		"+//            UNIT=SYSDA,SPACE=(CYL,(1,1),RLSE) TEMP WORK FILE          00110000\n",
+		// No room left on the card: SPACE goes on a continuation card.
+		"+//OUT      DD DSN=FIX.NEW.OUTPUT.WITH.LONG.NAME,DISP=(NEW,CATLG),       00130000\n+//            SPACE=(CYL,(1,1),RLSE)\n",
+	} {
+		if !strings.Contains(diff.String(), want) {
+			t.Errorf("diff lacks %q:\n%s", want, diff.String())
+		}
+	}
+	if strings.Contains(diff.String(), "-//SYSIN    DD DUMMY") || strings.Contains(diff.String(), "-//USE") {
+		t.Errorf("diff touches unchanged cards:\n%s", diff.String())
+	}
+
+	// Edits are checked against the member: a changed member is refused.
+	stale := strings.Replace(fixJob, "//GEN      EXEC", "//GEN2     EXEC", 1)
+	if err := os.WriteFile(member, []byte(stale), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, skipped := fix.Plan(findings); len(skipped) != 1 || skipped[0].RuleID != "IEBGENER-REDUNDANT-COPY" {
+		t.Errorf("stale member: skipped %+v", skipped)
+	}
+	if err := os.WriteFile(member, []byte(fixJob), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Applied, the fixed rules no longer fire and the member still parses.
+	if err := fix.Apply(changes); err != nil {
+		t.Fatal(err)
+	}
+	run, diags := parser.Parse(dir)
+	if diags.Errors() > 0 {
+		t.Fatalf("rewritten member has parse errors: %+v", diags)
+	}
+	for _, f := range rules.Evaluate(&run) {
+		if f.Fix != nil {
+			t.Errorf("%s still fixable after apply: %s", f.RuleID, f.Evidence)
+		}
+	}
+	if got := run.Jobs[0].Steps; len(got) != 2 || got[0].Name != "GEN" || got[1].Name != "USE" {
+		t.Errorf("steps after apply: %+v", got)
+	}
+}
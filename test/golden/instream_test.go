@@ -0,0 +1,75 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/parser"
+)
+
+const instreamJob = `//INSTREAM JOB (1),'DATA',CLASS=A
+//S1       EXEC PGM=SORT
+//SORTIN   DD DSN=PROD.IN,DISP=SHR
+//SYMNAMES DD *
+CUSTNO,1,8,CH
+//DFSPARM  DD DATA,DLM=$$
+  OPTION DYNALLOC
+//* not a comment inside DD DATA
+/*
+$$
+//SYSIN    DD *,DLM='@@'
+  SORT FIELDS=COPY
+/*
+@@
+//S2       EXEC PGM=IDCAMS
+  REPRO INFILE(IN) OUTFILE(OUT)
+/*
+//S3       EXEC PGM=IEBGENER
+//SYSUT1   DD *
+LAST RECORD
+`
+
+func TestParser_InstreamData(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "instream.jcl"), []byte(instreamJob), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run, _ := parser.Parse(dir)
+	if len(run.Jobs) != 1 || len(run.Jobs[0].Steps) != 3 {
+		t.Fatalf("unexpected shape: %+v", run.Jobs)
+	}
+	steps := run.Jobs[0].Steps
+
+	cases := []struct {
+		step            int
+		dd              string
+		content         string
+		form, delimiter string
+	}{
+		// DD * ended implicitly by the next JCL card.
+		{0, "SYMNAMES", "CUSTNO,1,8,CH\n", "*", "//"},
+		// DD DATA keeps // and /* records until the DLM.
+		{0, "DFSPARM", "  OPTION DYNALLOC\n//* not a comment inside DD DATA\n/*\n", "DATA", "$$"},
+		// DLM= replaces /* on DD * too.
+		{0, "SYSIN", "  SORT FIELDS=COPY\n/*\n", "*", "@@"},
+		// Data after EXEC without a DD gets the implicit SYSIN DD *.
+		{1, "SYSIN", "  REPRO INFILE(IN) OUTFILE(OUT)\n", "*", "/*"},
+		// Data running to the end of the member.
+		{2, "SYSUT1", "LAST RECORD\n", "*", "EOF"},
+	}
+	for _, c := range cases {
+		dd := findDD(steps[c.step], c.dd)
+		if dd == nil {
+			t.Errorf("%s.%s not found", steps[c.step].Name, c.dd)
+			continue
+		}
+		if dd.Content != c.content || dd.Instream != c.form || dd.Delimiter != c.delimiter {
+			t.Errorf("%s.%s: content=%q form=%q delimiter=%q; want %q %q %q",
+				steps[c.step].Name, c.dd, dd.Content, dd.Instream, dd.Delimiter, c.content, c.form, c.delimiter)
+		}
+	}
+	if findDD(steps[0], "SORTIN").Instream != "" {
+		t.Errorf("SORTIN should not carry in-stream data")
+	}
+}
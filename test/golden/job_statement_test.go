@@ -0,0 +1,55 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/parser"
+)
+
+const twoJobs = `//NIGHTLY1 JOB (ACCT01,DEPT9),'J SMITH',CLASS=B,MSGCLASS=X,
+//             NOTIFY=&SYSUID,REGION=0M,TIME=(5,30),USER=BATCH01
+/*JOBPARM SYSAFF=SYSA,LINES=100
+/*ROUTE PRINT RMT5
+//S1       EXEC PGM=IEFBR14
+//NIGHTLY2 JOB 1,OPS,CLASS=C,NOTIFY=OPSUSER,TYPRUN=SCAN,RESTART=S2
+//S1       EXEC PGM=IEFBR14
+//S2       EXEC PGM=IEFBR14
+`
+
+func TestParser_JobStatement(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "nightly.jcl"), []byte(twoJobs), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run, diags := parser.Parse(dir)
+	if len(diags.Items) != 0 {
+		t.Errorf("unexpected warnings: %v", diags.Items)
+	}
+	if len(run.Jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d", len(run.Jobs))
+	}
+
+	j1 := run.Jobs[0]
+	if j1.Name != "NIGHTLY1" || j1.Accounting != "(ACCT01,DEPT9)" || j1.Programmer != "J SMITH" ||
+		j1.Class != "B" || j1.MsgClass != "X" || j1.Notify != "&SYSUID" || j1.Region != "0M" ||
+		j1.Time != "(5,30)" || j1.User != "BATCH01" || j1.Owner != "BATCH01" {
+		t.Errorf("NIGHTLY1 JOB fields: %+v", j1)
+	}
+	if j1.JobParm["SYSAFF"] != "SYSA" || j1.JobParm["LINES"] != "100" {
+		t.Errorf("NIGHTLY1 /*JOBPARM: %v", j1.JobParm)
+	}
+	if len(j1.Route) != 1 || j1.Route[0] != "PRINT RMT5" {
+		t.Errorf("NIGHTLY1 /*ROUTE: %v", j1.Route)
+	}
+	if len(j1.Steps) != 1 {
+		t.Errorf("NIGHTLY1 steps: %d", len(j1.Steps))
+	}
+
+	j2 := run.Jobs[1]
+	if j2.Name != "NIGHTLY2" || j2.Class != "C" || j2.Owner != "OPSUSER" || j2.TypRun != "SCAN" ||
+		j2.Restart != "S2" || j2.Programmer != "OPS" || len(j2.Steps) != 2 {
+		t.Errorf("NIGHTLY2 JOB fields: %+v", j2)
+	}
+}
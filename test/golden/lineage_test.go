@@ -0,0 +1,157 @@
+package golden
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/api"
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/lineage"
+	"github.com/codewithboateng/jclift/internal/parser"
+	"github.com/codewithboateng/jclift/internal/storage"
+)
+
+const lineageExtract = `//EXTRACT  JOB (1),'EXTRACT',CLASS=A
+//PULL     EXEC PGM=SORT
+//SORTIN   DD DSN=PROD.PAY.MASTER,DISP=SHR
+//SORTOUT  DD DSN=&&PAYTMP,DISP=(NEW,PASS),SPACE=(CYL,(5,1))
+//SYSIN    DD *
+  SORT FIELDS=(1,8,CH,A)
+/*
+//LOAD     EXEC PGM=IEBGENER
+//SYSUT1   DD DSN=&&PAYTMP,DISP=(OLD,DELETE)
+//SYSUT2   DD DSN=PROD.PAY.DAILY(+1),DISP=(NEW,CATLG),SPACE=(CYL,(5,1))
+`
+
+const lineageReport = `//REPORT   JOB (1),'REPORT',CLASS=A
+//PRINT    EXEC PGM=PAYRPT
+//INFILE   DD DSN=PROD.PAY.DAILY(0),DISP=SHR
+//OUTFILE  DD DSN=PROD.PAY.REPORT,DISP=OLD
+//SYSOUT   DD SYSOUT=*
+`
+
+func TestLineage_Graph(t *testing.T) {
+	dir := t.TempDir()
+	for name, body := range map[string]string{"extract.jcl": lineageExtract, "report.jcl": lineageReport} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run, _ := parser.ParseWithOptions(dir, parser.Options{})
+	g := lineage.Build(&run)
+
+	type edge struct{ dataset, gen, step, dd, access string }
+	var got []edge
+	for _, e := range g.Edges {
+		got = append(got, edge{e.Dataset, e.Generation, e.Job + "." + e.Step, e.DD, e.Access})
+	}
+	want := []edge{
+		{"PROD.PAY.MASTER", "", "EXTRACT.PULL", "SORTIN", ir.AccessRead},
+		{"EXTRACT.&&PAYTMP", "", "EXTRACT.PULL", "SORTOUT", ir.AccessCreate},
+		{"EXTRACT.&&PAYTMP", "", "EXTRACT.LOAD", "SYSUT1", ir.AccessRead},
+		{"PROD.PAY.DAILY", "+1", "EXTRACT.LOAD", "SYSUT2", ir.AccessCreate},
+		{"PROD.PAY.DAILY", "0", "REPORT.PRINT", "INFILE", ir.AccessRead},
+		{"PROD.PAY.REPORT", "", "REPORT.PRINT", "OUTFILE", ir.AccessWrite},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("edges:\n got %v\nwant %v", got, want)
+	}
+
+	kinds := map[string]ir.LineageDataset{}
+	for _, d := range g.Datasets {
+		kinds[d.Name] = d
+	}
+	if d := kinds["PROD.PAY.DAILY"]; d.Kind != ir.DatasetGDG || d.Producers != 1 || d.Consumers != 1 {
+		t.Errorf("GDG node: %+v", d)
+	}
+	if d := kinds["EXTRACT.&&PAYTMP"]; d.Kind != ir.DatasetTemp || d.Producers != 1 || d.Consumers != 1 {
+		t.Errorf("temp node: %+v", d)
+	}
+
+	// Upstream of the temp dataset is PULL and the master; downstream LOAD,
+	// the GDG and the REPORT job reading it.
+	tr := lineage.Trace(g, "extract.&&paytmp", 0)
+	if len(tr.Edges) != 6 || tr.Edges[0].Dataset != "PROD.PAY.MASTER" || tr.Edges[5].Dataset != "PROD.PAY.REPORT" {
+		t.Errorf("trace: %+v", tr.Edges)
+	}
+	if tr := lineage.Trace(g, "PROD.PAY.DAILY(+1)", 1); len(tr.Edges) != 4 {
+		t.Errorf("trace depth 1: %+v", tr.Edges)
+	}
+
+	var dot, mermaid strings.Builder
+	if err := lineage.Write(&dot, g, "dot"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(dot.String(), `"s:EXTRACT.LOAD" -> "d:PROD.PAY.DAILY" [label="SYSUT2 (+1)"];`) {
+		t.Errorf("dot output:\n%s", dot.String())
+	}
+
+	// A dataset named like a JOB.STEP is still its own node.
+	var clash strings.Builder
+	if err := lineage.WriteDOT(&clash, lineage.FromEdges([]ir.LineageEdge{
+		{Dataset: "PAY.COPY", Job: "PAY", Step: "COPY", DD: "SYSUT2", Access: ir.AccessCreate},
+	})); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"d:PAY.COPY" [shape=cylinder, label="PAY.COPY"];`,
+		`"s:PAY.COPY" [shape=box, label="PAY.COPY"];`,
+		`"s:PAY.COPY" -> "d:PAY.COPY" [label="SYSUT2"];`,
+	} {
+		if !strings.Contains(clash.String(), want) {
+			t.Errorf("dot output lacks %s:\n%s", want, clash.String())
+		}
+	}
+	if err := lineage.Write(&mermaid, g, "mermaid"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(mermaid.String(), "flowchart LR\n") || !strings.Contains(mermaid.String(), `-->|"INFILE (0)"|`) {
+		t.Errorf("mermaid output:\n%s", mermaid.String())
+	}
+
+	// Stored alongside the run and read back unchanged.
+	db, err := storage.OpenSQLite(filepath.Join(t.TempDir(), "jclift.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.CreateSchema(); err != nil {
+		t.Fatal(err)
+	}
+	run.ID, run.Lineage = "run_lineage", g
+	if err := db.SaveRun(&run); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := db.LoadLineage(run.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range g.Edges { // only the card's file and line are stored
+		g.Edges[i].Loc = &ir.Location{File: g.Edges[i].Loc.File, Line: g.Edges[i].Loc.Line}
+	}
+	if !reflect.DeepEqual(stored, g) {
+		t.Errorf("stored lineage differs:\n got %+v\nwant %+v", stored, g)
+	}
+
+	// Served by /api/v1/runs/{id}/lineage, 404 for unknown runs.
+	srv := httptest.NewServer((&api.Server{DB: db}).Routes())
+	defer srv.Close()
+	for path, want := range map[string]int{
+		"/api/v1/runs/run_lineage/lineage?format=dot": http.StatusOK,
+		"/api/v1/runs/no-such-run/lineage":            http.StatusNotFound,
+	} {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("%s: status %d, want %d", path, resp.StatusCode, want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/parser"
+	"github.com/codewithboateng/jclift/internal/rules"
+)
+
+const locationJob = `//LOCJOB   JOB (1),'LOC',CLASS=A
+//* comment card
+//S1       EXEC PGM=SORT
+//SORTIN   DD DSN=PROD.IN,
+//            DISP=OLD
+//SORTOUT  DD DSN=PROD.OUT,DISP=SHR
+//S2       EXEC PGM=IEFBR14
+`
+
+func TestParser_SourceLocations(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "loc.jcl")
+	if err := os.WriteFile(file, []byte(locationJob), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run, _ := parser.Parse(dir)
+	if len(run.Jobs) != 1 || len(run.Jobs[0].Steps) != 2 {
+		t.Fatalf("unexpected shape: %+v", run.Jobs)
+	}
+	job := &run.Jobs[0]
+	want := func(what string, got *ir.Location, line, endLine, endCol int) {
+		t.Helper()
+		if got == nil || got.File != file || got.Line != line || got.EndLine != endLine || got.Col != 1 || got.EndCol != endCol {
+			t.Errorf("%s: got %+v; want %s lines %d-%d end col %d", what, got, file, line, endLine, endCol)
+		}
+	}
+	want("job", job.Loc, 1, 7, 27)
+	want("S1", job.Steps[0].Loc, 3, 6, 35)
+	want("S1.SORTIN", findDD(job.Steps[0], "SORTIN").Loc, 4, 5, 22)
+	want("S2", job.Steps[1].Loc, 7, 7, 27)
+
+	rules.SetSettings(rules.Settings{SeverityThreshold: "LOW"})
+	var dispOld, missingSysin *ir.Finding
+	for _, f := range rules.Evaluate(&run) {
+		switch f.RuleID {
+		case "DD-DISP-OLD-SERIALIZATION":
+			dispOld = &f
+		case "SORT-MISSING-SYSIN":
+			missingSysin = &f
+		}
+	}
+	if dispOld == nil || missingSysin == nil {
+		t.Fatalf("expected DISP=OLD and missing SYSIN findings")
+	}
+	if dispOld.DD != "SORTIN" {
+		t.Errorf("DISP=OLD finding DD = %q", dispOld.DD)
+	}
+	want("DISP=OLD finding", dispOld.Loc, 4, 5, 22)
+	want("missing SYSIN finding", missingSysin.Loc, 3, 6, 35)
+}
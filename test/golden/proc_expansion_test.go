@@ -0,0 +1,115 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/parser"
+)
+
+const procJob = `//PROCJOB  JOB (1),'PROCS',CLASS=A
+//LIBS     JCLLIB ORDER=(APP.PROCLIB)
+//INSTR    PROC
+//COPY     EXEC PGM=IEBGENER
+//SYSUT1   DD DSN=IN.DATA,DISP=SHR
+//SYSUT2   DD DSN=OUT.DATA,DISP=(NEW,CATLG,DELETE),SPACE=(CYL,(5,1))
+//SYSIN    DD DUMMY
+//         PEND
+//J1       EXEC INSTR
+//COPY.SYSUT1 DD DSN=OVERRIDE.IN,DISP=OLD
+//COPY.EXTRA  DD DSN=ADDED.DD,DISP=SHR
+//J2       EXEC PROC=SORTPROC,REGION.SORTIT=64M
+//SORTIT.SYSIN DD *
+  SORT FIELDS=COPY
+/*
+//         INCLUDE MEMBER=TRAILER
+//J4       EXEC MISSING
+`
+
+const sortProc = `//SORTPROC PROC
+//SORTIT   EXEC PGM=SORT,REGION=0M
+//SORTIN   DD DSN=SORT.IN,DISP=SHR
+//SORTOUT  DD DSN=SORT.OUT,DISP=(NEW,CATLG)
+//SYSIN    DD DUMMY
+`
+
+const trailerInclude = `//J3       EXEC PGM=IEFBR14
+//DEL      DD DSN=SORT.IN,DISP=(OLD,DELETE)
+`
+
+func TestParser_ProcExpansion(t *testing.T) {
+	root := t.TempDir()
+	jobs := filepath.Join(root, "jobs")
+	procs := filepath.Join(root, "proclib")
+	for _, d := range []string{jobs, procs} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write := func(p, s string) {
+		if err := os.WriteFile(p, []byte(s), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(jobs, "procjob.jcl"), procJob)
+	write(filepath.Join(procs, "SORTPROC"), sortProc)
+	write(filepath.Join(procs, "trailer.jcl"), trailerInclude)
+
+	run, diags := parser.ParseWithOptions(jobs, parser.Options{
+		ProcLibs: []parser.ProcLib{{Dir: procs, Dataset: "APP.PROCLIB"}},
+	})
+	if len(run.Jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d (warnings=%v)", len(run.Jobs), diags.Items)
+	}
+	job := run.Jobs[0]
+	if job.ProcsResolved {
+		t.Fatalf("expected ProcsResolved=false with an unresolved PROC")
+	}
+
+	want := []struct{ name, pgm, proc, procStep string }{
+		{"J1.COPY", "IEBGENER", "INSTR", "COPY"},
+		{"J2.SORTIT", "SORT", "SORTPROC", "SORTIT"},
+		{"J3", "IEFBR14", "", ""},
+		{"J4", "UNKNOWN", "MISSING", ""},
+	}
+	if len(job.Steps) != len(want) {
+		t.Fatalf("expected %d steps, got %d: %+v", len(want), len(job.Steps), job.Steps)
+	}
+	for i, w := range want {
+		st := job.Steps[i]
+		if st.Name != w.name || st.Program != w.pgm || st.Proc != w.proc || st.ProcStep != w.procStep {
+			t.Errorf("step %d: got name=%s pgm=%s proc=%s procstep=%s; want %+v",
+				i, st.Name, st.Program, st.Proc, st.ProcStep, w)
+		}
+	}
+
+	copyStep := job.Steps[0]
+	if dd := findDD(copyStep, "SYSUT1"); dd == nil || dd.Dataset != "OVERRIDE.IN" || dd.DISP != "OLD" {
+		t.Errorf("SYSUT1 override not applied: %+v", dd)
+	}
+	if dd := findDD(copyStep, "SYSUT2"); dd == nil || dd.Space != "(CYL,(5,1))" {
+		t.Errorf("SYSUT2 from PROC lost: %+v", dd)
+	}
+	if findDD(copyStep, "EXTRA") == nil {
+		t.Errorf("added DD EXTRA missing")
+	}
+
+	sortStep := job.Steps[1]
+	if dd := findDD(sortStep, "SYSIN"); dd == nil || dd.Content == "" || dd.Content == "DUMMY" {
+		t.Errorf("SYSIN in-stream override not applied: %+v", dd)
+	}
+	if len(diags.Items) == 0 {
+		t.Errorf("expected a warning for PROC MISSING")
+	}
+}
+
+func findDD(st ir.Step, name string) *ir.DD {
+	for i := range st.DD {
+		if st.DD[i].DDName == name {
+			return &st.DD[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/parser"
+)
+
+const referbackJob = `//REFJOB   JOB (1),'REFS',CLASS=A
+//COPY     PROC
+//RUN      EXEC PGM=IEBGENER
+//SYSUT1   DD DSN=PROD.IN,DISP=SHR
+//SYSUT2   DD DSN=PROD.COPY,DISP=(NEW,CATLG),SPACE=(TRK,(1,1))
+//CHECK    EXEC PGM=IEBGENER
+//SYSUT1   DD DSN=*.RUN.SYSUT2,DISP=SHR
+//SYSUT2   DD DSN=&&CHK,DISP=(NEW,PASS)
+//         PEND
+//S1       EXEC PGM=IEBGENER
+//SYSUT1   DD DSN=PROD.A,DISP=SHR
+//         DD DSN=PROD.B,DISP=SHR
+//         DD DSN=PROD.C,DISP=SHR
+//SYSUT2   DD DSN=&&TEMP,DISP=(NEW,PASS),SPACE=(CYL,(1,1))
+//S2       EXEC COPY
+//S3       EXEC PGM=SORT
+//SORTIN   DD DSN=*.S1.SYSUT2,DISP=(OLD,DELETE)
+//         DD DSN=*.S2.RUN.SYSUT2,DISP=SHR
+//SORTOUT  DD DSN=PROD.OUT,DISP=(NEW,CATLG),VOL=REF=*.S2.CHECK.SYSUT1,
+//            DCB=*.SORTIN
+//MISSING  DD DSN=*.NOSTEP.DD1,DISP=SHR
+`
+
+func TestParser_ConcatenationAndReferbacks(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "refs.jcl"), []byte(referbackJob), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run, diags := parser.Parse(dir)
+	if len(run.Jobs) != 1 || len(run.Jobs[0].Steps) != 4 {
+		t.Fatalf("unexpected shape: %+v", run.Jobs)
+	}
+	steps := run.Jobs[0].Steps
+
+	s1 := steps[0]
+	if len(s1.DD) != 2 {
+		t.Fatalf("S1: expected 2 logical DDs, got %d", len(s1.DD))
+	}
+	ut1 := findDD(s1, "SYSUT1")
+	if ut1 == nil || len(ut1.Concat) != 2 {
+		t.Fatalf("S1.SYSUT1 concatenation: %+v", ut1)
+	}
+	var names []string
+	for _, m := range ut1.Members() {
+		if m.DDName != "SYSUT1" {
+			t.Errorf("member DDName = %q", m.DDName)
+		}
+		names = append(names, m.Dataset)
+	}
+	if got := names[0] + "," + names[1] + "," + names[2]; got != "PROD.A,PROD.B,PROD.C" {
+		t.Errorf("S1.SYSUT1 members: %s", got)
+	}
+
+	// *.procstep.ddname inside an expanded PROC resolves within the invocation.
+	if dd := findDD(steps[2], "SYSUT1"); steps[2].Name != "S2.CHECK" || dd == nil ||
+		dd.Dataset != "PROD.COPY" || dd.Referback != "*.RUN.SYSUT2" {
+		t.Errorf("S2.CHECK.SYSUT1: step=%s dd=%+v", steps[2].Name, dd)
+	}
+
+	s3 := steps[3]
+	in := findDD(s3, "SORTIN")
+	if in == nil || in.Dataset != "&&TEMP" || !in.Temp || in.Referback != "*.S1.SYSUT2" {
+		t.Errorf("S3.SORTIN: %+v", in)
+	}
+	if in != nil && (len(in.Concat) != 1 || in.Concat[0].Dataset != "PROD.COPY" || in.Concat[0].Referback != "*.S2.RUN.SYSUT2") {
+		t.Errorf("S3.SORTIN concat: %+v", in.Concat)
+	}
+	out := findDD(s3, "SORTOUT")
+	if out == nil || out.Vol == nil || out.Vol.Ref != "PROD.COPY" || out.Attrs == nil || out.Attrs.Model != "&&TEMP" {
+		t.Errorf("S3.SORTOUT: %+v", out)
+	}
+	if miss := findDD(s3, "MISSING"); miss == nil || miss.Dataset != "*.NOSTEP.DD1" || miss.Referback != "" {
+		t.Errorf("S3.MISSING: %+v", miss)
+	}
+	if len(diags.Items) != 1 {
+		t.Errorf("expected one unresolved-referback warning, got %v", diags.Items)
+	}
+}
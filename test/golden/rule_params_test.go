@@ -0,0 +1,106 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/parser"
+	"github.com/codewithboateng/jclift/internal/rules"
+	"github.com/codewithboateng/jclift/internal/shared"
+)
+
+const ruleParamsJob = `//PARMJOB  JOB (1),'PARAMS',CLASS=A
+//S1       EXEC PGM=SORT
+//SORTIN   DD DSN=PROD.IN,DISP=SHR
+//SORTOUT  DD DSN=PROD.OUT,DISP=OLD
+//SORTWK01 DD UNIT=SYSDA,SPACE=(CYL,(300,10))
+//SYSIN    DD *
+  SORT FIELDS=COPY
+/*
+`
+
+const ruleParamsConfig = `rules:
+  severity_threshold: LOW
+  sortwk:
+    primary_cyl_threshold: 500
+  SORT-SORTWK-OVERSIZED:
+    severity: high
+    params:
+      primary_cyl_threshold: 200
+      savings_mips_per_dd: 1.5
+  sort-identity:
+    severity: LOW
+`
+
+func TestRules_ParamsAndSeverityOverrides(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "parm.jcl"), []byte(ruleParamsJob), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfgPath := filepath.Join(t.TempDir(), "jclift.yaml")
+	if err := os.WriteFile(cfgPath, []byte(ruleParamsConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := shared.LoadConfig(cfgPath)
+
+	s := rules.Settings{
+		SeverityThreshold:         cfg.Rules.SeverityThreshold,
+		SortwkPrimaryCylThreshold: cfg.Rules.Sortwk.PrimaryCylThreshold,
+		Severity:                  map[string]string{},
+		Params:                    map[string]map[string]any{},
+	}
+	for id, rc := range cfg.Rules.PerRule {
+		s.Severity[id] = rc.Severity
+		if rc.Params != nil {
+			s.Params[id] = rc.Params
+		}
+	}
+	if err := rules.CheckSettings(s); err != nil {
+		t.Fatal(err)
+	}
+	rules.SetSettings(s)
+	t.Cleanup(func() {
+		rules.SetSettings(rules.Settings{SeverityThreshold: "LOW", SortwkPrimaryCylThreshold: 500})
+	})
+
+	run, _ := parser.Parse(dir)
+	sev := map[string]string{}
+	savings := map[string]float64{}
+	for _, f := range rules.Evaluate(&run) {
+		sev[f.RuleID], savings[f.RuleID] = f.Severity, f.SavingsMIPS
+	}
+	// 300 cylinders only exceed the configured threshold of 200.
+	if sev["SORT-SORTWK-OVERSIZED"] != "HIGH" || savings["SORT-SORTWK-OVERSIZED"] != 1.5 {
+		t.Errorf("SORTWK: severity %q savings %v", sev["SORT-SORTWK-OVERSIZED"], savings["SORT-SORTWK-OVERSIZED"])
+	}
+	if sev["SORT-IDENTITY"] != "LOW" {
+		t.Errorf("SORT-IDENTITY severity %q, want override LOW", sev["SORT-IDENTITY"])
+	}
+	// Rules without an override report their DefaultSeverity.
+	if r, _ := rules.Get("DD-DISP-OLD-SERIALIZATION"); sev[r.ID] != r.DefaultSeverity || r.DefaultSeverity == "" {
+		t.Errorf("DD-DISP-OLD-SERIALIZATION severity %q, default %q", sev[r.ID], r.DefaultSeverity)
+	}
+
+	severities, params := rules.Effective()
+	if severities["SORT-SORTWK-OVERSIZED"] != "HIGH" || severities["SORT-IDENTITY"] != "LOW" {
+		t.Errorf("effective severities %v", severities)
+	}
+	if params["SORT-SORTWK-OVERSIZED"]["primary_cyl_threshold"] != 200 || params["SORT-IDENTITY"]["fallback_savings_mips"] != 0.8 {
+		t.Errorf("effective params %v", params)
+	}
+
+	bad := rules.Settings{
+		Severity: map[string]string{"NO-SUCH-RULE": "HIGH", "SORT-IDENTITY": "URGENT"},
+		Params: map[string]map[string]any{
+			"SORT-SORTWK-OVERSIZED": {"primary_cyl_threshold": "lots", "typo": 1},
+		},
+	}
+	err := rules.CheckSettings(bad)
+	for _, want := range []string{"unknown rule NO-SUCH-RULE", `severity "URGENT"`, "primary_cyl_threshold: lots is not a valid int", `no parameter "typo"`} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("CheckSettings: %v; want %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package golden
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/api"
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/parser"
+	"github.com/codewithboateng/jclift/internal/rules"
+	"github.com/codewithboateng/jclift/internal/storage"
+)
+
+const statsJobs = `//STATSOK  JOB (1),'STATS',CLASS=A
+//S1       EXEC PGM=SORT
+//SORTIN   DD DSN=STATS.IN,DISP=SHR
+//SORTOUT  DD DSN=STATS.OUT,DISP=SHR
+//SYSIN    DD *
+  SORT FIELDS=COPY
+/*
+//STATSBAD JOB (1),'STATS',CLASS=A
+//S1       EXEC PGM=SORT
+//SORTIN   DD DSN=STATS.IN,DISP=SHR
+//SORTOUT  DD DSN=STATS.OUT2,DISP=SHR
+//SYSIN    DD *
+  SORT FIELDS=COPY
+/*
+`
+
+// TEST-PANIC-ON-STATSBAD panics on one job only; other runs never see it.
+func registerPanickyRule() {
+	if _, ok := rules.Get("TEST-PANIC-ON-STATSBAD"); ok {
+		return
+	}
+	rules.Register(rules.Rule{
+		ID:              "TEST-PANIC-ON-STATSBAD",
+		Summary:         "Test rule that panics on job STATSBAD",
+		Type:            "RISK",
+		DefaultSeverity: "LOW",
+		Eval: func(job *ir.Job) []ir.Finding {
+			if job.Name == "STATSBAD" {
+				var steps []ir.Step
+				_ = steps[len(job.Steps)] // index out of range
+			}
+			return nil
+		},
+	})
+}
+
+func TestRules_EvaluateStats(t *testing.T) {
+	registerPanickyRule()
+	rules.SetSettings(rules.Settings{SeverityThreshold: "MEDIUM"})
+	defer rules.SetSettings(rules.Settings{})
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "stats.jcl"), []byte(statsJobs), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run, _ := parser.Parse(dir)
+	run.ID = "run-stats"
+	run.Findings = rules.Evaluate(&run) // must not panic
+
+	byRule := map[string]ir.RuleStat{}
+	for _, st := range run.Context.RuleStats {
+		byRule[st.Rule] = st
+	}
+	if len(byRule) != len(run.Context.RuleStats) {
+		t.Errorf("duplicate rule stats: %+v", run.Context.RuleStats)
+	}
+
+	bad := byRule["TEST-PANIC-ON-STATSBAD"]
+	if bad.Scope != rules.ScopeJob || bad.Invocations != 2 || bad.Panics != 1 || bad.Findings != 0 {
+		t.Errorf("panicking rule stats %+v", bad)
+	}
+	if !strings.HasPrefix(bad.LastPanic, "job STATSBAD: ") {
+		t.Errorf("last panic %q", bad.LastPanic)
+	}
+
+	// Both jobs copy with SORT; SORT-IDENTITY still ran on the job the
+	// other rule panicked on.
+	si := byRule["SORT-IDENTITY"]
+	if si.Scope != rules.ScopeJob || si.Invocations != 2 || si.Findings != 2 || si.Panics != 0 {
+		t.Errorf("SORT-IDENTITY stats %+v", si)
+	}
+	reported := 0
+	for _, f := range run.Findings {
+		if f.RuleID == "SORT-IDENTITY" {
+			reported++
+		}
+	}
+	if si.Findings-si.Suppressed != reported {
+		t.Errorf("SORT-IDENTITY: %d findings, %d suppressed, %d reported", si.Findings, si.Suppressed, reported)
+	}
+	if si.Max > si.Elapsed || si.Mean() > si.Max {
+		t.Errorf("SORT-IDENTITY timings %+v", si)
+	}
+	for _, st := range run.Context.RuleStats {
+		if st.Scope == rules.ScopeRun && st.Invocations != 1 {
+			t.Errorf("run-scoped %s invoked %d times", st.Rule, st.Invocations)
+		}
+	}
+
+	// Stored with the run and served by /api/v1/runs/{id}/stats
+	db, err := storage.OpenSQLite(filepath.Join(t.TempDir(), "jclift.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.CreateSchema(); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveRun(&run); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer((&api.Server{DB: db}).Routes())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/api/v1/runs/run-stats/stats?sort=panics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var body struct {
+		RunID  string        `json:"run_id"`
+		Rules  []ir.RuleStat `json:"rules"`
+		Totals struct {
+			Rules  int `json:"rules"`
+			Panics int `json:"panics"`
+		} `json:"totals"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || body.RunID != "run-stats" || len(body.Rules) != len(run.Context.RuleStats) {
+		t.Fatalf("stats: status %d, %+v", resp.StatusCode, body)
+	}
+	if body.Rules[0].Rule != "TEST-PANIC-ON-STATSBAD" || body.Totals.Panics != 1 || body.Totals.Rules != len(body.Rules) {
+		t.Errorf("stats sorted by panics: first %s, totals %+v", body.Rules[0].Rule, body.Totals)
+	}
+	if body.Rules[0].Elapsed != bad.Elapsed {
+		t.Errorf("elapsed not stored: %s vs %s", body.Rules[0].Elapsed, bad.Elapsed)
+	}
+
+	for path, want := range map[string]int{
+		"/api/v1/runs/no-such-run/stats":         http.StatusNotFound,
+		"/api/v1/runs/run-stats/stats?sort=nope": http.StatusBadRequest,
+	} {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("%s: status %d, want %d", path, resp.StatusCode, want)
+		}
+	}
+}
@@ -214,6 +214,9 @@ func normalize(run ir.Run) runLite {
 		return finds[i].Step < finds[k].Step
 	})
 
+	// Rule timings vary from run to run
+	run.Context.RuleStats = nil
+
 	return runLite{
 		ID:        "run-golden",
 		StartedAt: "", // zeroed
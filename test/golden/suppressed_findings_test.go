@@ -0,0 +1,106 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/ir"
+	"github.com/codewithboateng/jclift/internal/parser"
+	"github.com/codewithboateng/jclift/internal/reporting"
+	"github.com/codewithboateng/jclift/internal/rules"
+	"github.com/codewithboateng/jclift/internal/storage"
+)
+
+const suppressedJob = `//SUPJOB   JOB (1),'SUPPRESS',CLASS=A
+//S1       EXEC PGM=SORT
+//SORTIN   DD DSN=PROD.IN,DISP=OLD
+//SORTOUT  DD DSN=PROD.OUT,DISP=MOD
+//SYSIN    DD *
+  SORT FIELDS=COPY
+/*
+`
+
+func TestRules_SeverityThresholdAndSuppression(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "sup.jcl"), []byte(suppressedJob), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rules.SetSettings(rules.Settings{
+		SeverityThreshold: "MEDIUM",
+		Disabled:          map[string]bool{"DD-DISP-MOD-APPEND": true},
+		RecordDisabled:    true,
+		Severity:          map[string]string{"DD-DISP-MOD-APPEND": "HIGH"},
+	})
+	t.Cleanup(func() {
+		rules.SetSettings(rules.Settings{SeverityThreshold: "LOW", SortwkPrimaryCylThreshold: 500})
+	})
+
+	run, _ := parser.Parse(dir)
+	run.ID = "run-suppressed"
+	run.Findings = rules.Evaluate(&run)
+
+	for _, f := range run.Findings {
+		if f.Severity == "LOW" || f.RuleID == "DD-DISP-MOD-APPEND" {
+			t.Errorf("reported %s (%s) despite threshold/disable", f.RuleID, f.Severity)
+		}
+	}
+	reasons := map[string]string{}
+	for _, sf := range run.SuppressedFindings {
+		reasons[sf.RuleID] = sf.Reason
+	}
+	if reasons["DD-DISP-MOD-APPEND"] != ir.SuppressedDisabled || reasons["DD-DISP-OLD-SERIALIZATION"] != ir.SuppressedThreshold {
+		t.Fatalf("suppression reasons %v", reasons)
+	}
+
+	// Waived findings carry the waiver that matched.
+	kept, waived := rules.ApplyWaivers(run.Findings, []storage.Waiver{{ID: 42, RuleID: "SORT-IDENTITY", Job: "SUPJOB"}})
+	if len(waived) != 1 || waived[0].Reason != ir.SuppressedWaived || waived[0].WaiverID != 42 {
+		t.Fatalf("waived %+v", waived)
+	}
+	run.Findings = kept
+	run.SuppressedFindings = append(run.SuppressedFindings, waived...)
+
+	out := t.TempDir()
+	path, err := reporting.WriteHTML(run.ID, out, &run)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := os.ReadFile(path)
+	for _, want := range []string{"Suppressed: 3", "below threshold 1, disabled rules 1, waived 1", "waived (waiver #42)"} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("HTML report lacks %q", want)
+		}
+	}
+}
+
+// Disabled rules do not run unless their findings are to be recorded.
+func TestRules_DisabledRulesSkipped(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "sup.jcl"), []byte(suppressedJob), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rules.SetSettings(rules.Settings{Disabled: map[string]bool{"DD-DISP-MOD-APPEND": true}})
+	t.Cleanup(func() {
+		rules.SetSettings(rules.Settings{SeverityThreshold: "LOW", SortwkPrimaryCylThreshold: 500})
+	})
+
+	run, _ := parser.Parse(dir)
+	run.Findings = rules.Evaluate(&run)
+	for _, f := range run.Findings {
+		if f.RuleID == "DD-DISP-MOD-APPEND" {
+			t.Errorf("disabled rule reported %+v", f)
+		}
+	}
+	for _, sf := range run.SuppressedFindings {
+		if sf.RuleID == "DD-DISP-MOD-APPEND" {
+			t.Errorf("disabled rule suppressed %+v", sf)
+		}
+	}
+	for _, st := range run.Context.RuleStats {
+		if st.Rule == "DD-DISP-MOD-APPEND" {
+			t.Errorf("disabled rule ran: %+v", st)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/parser"
+)
+
+const symbolJob = `//SYMJOB   JOB (1),'SYMS',CLASS=A,NOTIFY=&SYSUID
+//         SET HLQ=PROD,ENV=DAILY
+//EXTRACT  PROC HLQ=TEST,SFX='OUT'
+//RUN      EXEC PGM=IEBGENER
+//SYSUT1   DD DSN=&HLQ..PAYROLL.&ENV..DATA,DISP=SHR
+//SYSUT2   DD DSN=&HLQ..PAYROLL.&SFX,DISP=(NEW,CATLG),SPACE=(TRK,(1,1))
+//         PEND
+//S1       EXEC PGM=SORT
+//SORTIN   DD DSN=&HLQ..MASTER(0),DISP=SHR
+//SORTOUT  DD DSN=&&SORTED,DISP=(NEW,PASS)
+//OWNER    DD DSN=&SYSUID..WORK,DISP=SHR
+//S2       EXEC EXTRACT
+//S3       EXEC EXTRACT,HLQ=QA,SFX=BKP
+`
+
+func TestParser_SymbolSubstitution(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "sym.jcl"), []byte(symbolJob), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run, diags := parser.ParseWithOptions(dir, parser.Options{
+		Symbols: map[string]string{"&SYSUID": "BATCH01"},
+	})
+	if len(run.Jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(run.Jobs))
+	}
+	steps := run.Jobs[0].Steps
+
+	cases := []struct {
+		step, dd, dataset, raw string
+	}{
+		{"S1", "SORTIN", "PROD.MASTER(0)", "&HLQ..MASTER(0)"},
+		{"S1", "SORTOUT", "&&SORTED", ""},
+		{"S1", "OWNER", "BATCH01.WORK", "&SYSUID..WORK"},
+		// PROC default HLQ=TEST overrides SET; ENV comes from SET.
+		{"S2.RUN", "SYSUT1", "TEST.PAYROLL.DAILY.DATA", "&HLQ..PAYROLL.&ENV..DATA"},
+		{"S2.RUN", "SYSUT2", "TEST.PAYROLL.OUT", "&HLQ..PAYROLL.&SFX"},
+		// EXEC overrides beat PROC defaults.
+		{"S3.RUN", "SYSUT1", "QA.PAYROLL.DAILY.DATA", "&HLQ..PAYROLL.&ENV..DATA"},
+		{"S3.RUN", "SYSUT2", "QA.PAYROLL.BKP", "&HLQ..PAYROLL.&SFX"},
+	}
+	for _, c := range cases {
+		var found bool
+		for _, st := range steps {
+			if st.Name != c.step {
+				continue
+			}
+			dd := findDD(st, c.dd)
+			if dd == nil {
+				break
+			}
+			found = true
+			if dd.Dataset != c.dataset || dd.DatasetRaw != c.raw {
+				t.Errorf("%s.%s: got dataset=%q raw=%q; want %q raw=%q",
+					c.step, c.dd, dd.Dataset, dd.DatasetRaw, c.dataset, c.raw)
+			}
+		}
+		if !found {
+			t.Errorf("%s.%s not found", c.step, c.dd)
+		}
+	}
+	if len(diags.Items) != 0 {
+		t.Errorf("unexpected warnings: %v", diags.Items)
+	}
+}
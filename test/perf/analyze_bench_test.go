@@ -1,11 +1,15 @@
 package perf
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/codewithboateng/jclift/internal/cost"
+	"github.com/codewithboateng/jclift/internal/ir"
 	"github.com/codewithboateng/jclift/internal/parser"
 	"github.com/codewithboateng/jclift/internal/rules"
 	"github.com/codewithboateng/jclift/internal/shared"
@@ -29,37 +33,73 @@ func BenchmarkAnalyze_Small(b *testing.B) {
 	}
 
 	cfg := shared.DefaultConfig()
-	rules.SetSettings(rules.Settings{
-		SeverityThreshold:         "LOW",
-		Disabled:                  map[string]bool{},
-		SortwkPrimaryCylThreshold: cfg.Rules.Sortwk.PrimaryCylThreshold,
-	})
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		run, _ := parser.Parse(dir)
-		// cost annotate
-		run.Context.MIPSToUSD = 250
-		run.Context.Geometry.TracksPerCyl = cfg.Cost.Geometry.TracksPerCyl
-		run.Context.Geometry.BytesPerTrack = cfg.Cost.Geometry.BytesPerTrack
-		run.Context.Model.MIPSPerCPU = cfg.Cost.Model.MIPSPerCPU
-		run.Context.Model.SortAlpha = cfg.Cost.Model.Sort.Alpha
-		run.Context.Model.SortBeta = cfg.Cost.Model.Sort.Beta
-		run.Context.Model.CopyAlpha = cfg.Cost.Model.Copy.Alpha
-		run.Context.Model.CopyBeta = cfg.Cost.Model.Copy.Beta
-		run.Context.Model.IDAlpha = cfg.Cost.Model.IDCAMS.Alpha
-		run.Context.Model.IDBeta = cfg.Cost.Model.IDCAMS.Beta
-
-		for j := range run.Jobs {
-			for k := range run.Jobs[j].Steps {
-				size := cost.EstimateSizeMB(&run.Jobs[j].Steps[k], run.Context.Geometry)
-				run.Jobs[j].Steps[k].Annotations.SizeMB = size
-				run.Jobs[j].Steps[k].Annotations.Cost = cost.Estimate(&run.Jobs[j].Steps[k], run.Context)
-			}
-		}
-		run.Findings = rules.Evaluate(&run)
+		run := analyze(b, dir, cfg, 1)
 		if len(run.Jobs) == 0 {
 			b.Fatal("no jobs parsed")
 		}
 	}
 }
+
+// BenchmarkAnalyze_10kJobs tracks parse → cost → rules throughput on a
+// synthetic library of 10,000 jobs (1,000 members of 10 jobs), sequentially
+// and with one worker per CPU.
+func BenchmarkAnalyze_10kJobs(b *testing.B) {
+	const members, jobsPerMember = 1000, 10
+	dir := b.TempDir()
+	for m := 0; m < members; m++ {
+		var sb strings.Builder
+		for j := 0; j < jobsPerMember; j++ {
+			sb.WriteString(strings.Replace(benchSample, "//B JOB", fmt.Sprintf("//J%05d%d JOB", m, j), 1))
+		}
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("m%05d.jcl", m)), []byte(sb.String()), 0o644); err != nil {
+			b.Fatal(err)
+		}
+	}
+	cfg := shared.DefaultConfig()
+
+	counts := []int{1}
+	if n := runtime.GOMAXPROCS(0); n > 1 {
+		counts = append(counts, n)
+	}
+	for _, workers := range counts {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				run := analyze(b, dir, cfg, workers)
+				if len(run.Jobs) != members*jobsPerMember {
+					b.Fatalf("parsed %d jobs, want %d", len(run.Jobs), members*jobsPerMember)
+				}
+			}
+			b.ReportMetric(float64(members*jobsPerMember*b.N)/b.Elapsed().Seconds(), "jobs/s")
+		})
+	}
+}
+
+// analyze runs the analyze pipeline (parse, cost annotate, rules) on dir.
+func analyze(b *testing.B, dir string, cfg shared.Config, workers int) ir.Run {
+	b.Helper()
+	rules.SetSettings(rules.Settings{
+		SeverityThreshold:         "LOW",
+		Disabled:                  map[string]bool{},
+		SortwkPrimaryCylThreshold: cfg.Rules.Sortwk.PrimaryCylThreshold,
+		Workers:                   workers,
+	})
+	run, _ := parser.ParseWithOptions(dir, parser.Options{Workers: workers})
+	run.Context.MIPSToUSD = 250
+	run.Context.Geometry.TracksPerCyl = cfg.Cost.Geometry.TracksPerCyl
+	run.Context.Geometry.BytesPerTrack = cfg.Cost.Geometry.BytesPerTrack
+	run.Context.Model.MIPSPerCPU = cfg.Cost.Model.MIPSPerCPU
+	run.Context.Model.SortAlpha = cfg.Cost.Model.Sort.Alpha
+	run.Context.Model.SortBeta = cfg.Cost.Model.Sort.Beta
+	run.Context.Model.CopyAlpha = cfg.Cost.Model.Copy.Alpha
+	run.Context.Model.CopyBeta = cfg.Cost.Model.Copy.Beta
+	run.Context.Model.IDAlpha = cfg.Cost.Model.IDCAMS.Alpha
+	run.Context.Model.IDBeta = cfg.Cost.Model.IDCAMS.Beta
+
+	cost.Annotate(&run, workers)
+	run.Findings = rules.Evaluate(&run)
+	return run
+}
@@ -0,0 +1,88 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/codewithboateng/jclift/internal/rules"
+	"github.com/codewithboateng/jclift/internal/rules/rulestest"
+	"github.com/codewithboateng/jclift/internal/rules/rulestesting"
+	"github.com/codewithboateng/jclift/internal/rulesdsl"
+)
+
+func TestFixtures_Builtin(t *testing.T) {
+	rulestesting.Test(t, "builtin", rulestest.Options{})
+}
+
+func TestFixtures_ExamplePack(t *testing.T) {
+	if _, ok := rules.Get("DSL-SORT-FIELDS-COPY"); !ok {
+		if _, err := rulesdsl.LoadAndRegister("../../configs/rules.example.yaml"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rulestesting.Test(t, "dsl", rulestest.Options{})
+}
+
+func TestFixtures_Mismatches(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "wrong.jcl")
+	fixture := `//* EXPECT: SORT-IDENTITY@OTHER, NO-SUCH-RULE
+//* RULES: DD-DISP-MOD-APPEND
+//WRONG    JOB (1),'WRONG',CLASS=A
+//COPY     EXEC PGM=SORT
+//SORTIN   DD DSN=FIX.IN,DISP=SHR
+//SORTOUT  DD DSN=FIX.LOG,DISP=MOD
+//SYSIN    DD *
+  SORT FIELDS=COPY
+/*
+`
+	if err := os.WriteFile(file, []byte(fixture), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fx, err := rulestest.Load(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// RULES replaces the rules named in EXPECT.
+	if len(fx.Rules) != 1 || fx.Rules[0] != "DD-DISP-MOD-APPEND" || len(fx.Expect) != 2 {
+		t.Fatalf("fixture: %+v", fx)
+	}
+
+	got := map[string]int{}
+	for _, m := range rulestest.Run(fx, rulestest.Options{}).Mismatches {
+		got[m.Kind+" "+m.Rule+"@"+m.Step]++
+		if m.Line == 0 {
+			t.Errorf("%s: no line", m)
+		}
+	}
+	want := map[string]int{
+		"missing SORT-IDENTITY@OTHER":        1, // the finding is at COPY
+		"missing NO-SUCH-RULE@":              1,
+		"unknown-rule NO-SUCH-RULE@":         1,
+		"unexpected DD-DISP-MOD-APPEND@COPY": 1,
+	}
+	if len(got) != len(want) {
+		t.Errorf("mismatches %v", got)
+	}
+	for k, n := range want {
+		if got[k] != n {
+			t.Errorf("mismatches %v, want %s", got, k)
+		}
+	}
+}
+
+// The DD is split off at the last dot, so PROC steps can be targeted.
+func TestFixtures_ProcStepTargets(t *testing.T) {
+	fx, err := rulestest.Load("builtin/proc_step.jcl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []rulestest.Expectation{
+		{Rule: "DD-NEW-MISSING-SPACE", Step: "JS1.PS1", DD: "OUT", Line: 2},
+		{Rule: "DD-NEW-MISSING-SPACE", Step: "JS2", DD: "PS1", Line: 3}, // matched as step JS2.PS1
+	}
+	if !reflect.DeepEqual(fx.Expect, want) {
+		t.Errorf("expectations %+v", fx.Expect)
+	}
+}